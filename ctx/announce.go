@@ -0,0 +1,42 @@
+package ctx
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/marcuspeh/go-tools/logger"
+)
+
+// Announcer is called with every log ID created by GetCtx and GetCtxFrom.
+type Announcer func(ctx context.Context, logID string)
+
+var announcer atomic.Pointer[Announcer]
+
+func init() {
+	SetAnnouncer(LogAnnouncer(zapcore.InfoLevel))
+}
+
+// LogAnnouncer announces new log IDs through the logger package at level.
+func LogAnnouncer(level zapcore.Level) Announcer {
+	return func(ctx context.Context, logID string) {
+		logger.Log(ctx, level, "log id created")
+	}
+}
+
+// SetAnnouncer replaces the global announcer. A nil announcer disables
+// announcements.
+func SetAnnouncer(a Announcer) {
+	announcer.Store(&a)
+}
+
+func SetAnnounceLevel(level zapcore.Level) {
+	SetAnnouncer(LogAnnouncer(level))
+}
+
+func announce(ctx context.Context, logID string) {
+	if a := *announcer.Load(); a != nil {
+		a(ctx, logID)
+	}
+}
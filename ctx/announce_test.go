@@ -0,0 +1,57 @@
+package ctx
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/marcuspeh/go-tools/logger"
+)
+
+func TestAnnounceThroughLogger(t *testing.T) {
+	observed := logger.NewObserved(t)
+	defer SetAnnounceLevel(zapcore.InfoLevel)
+
+	ctx, cancel := GetCtx("announced")
+	defer cancel()
+
+	logID, _ := LogIDFrom(ctx)
+	entries := observed.FilterLogID(logID)
+	if len(entries) != 1 || entries[0].Level != zapcore.InfoLevel {
+		t.Fatalf("Expected one info announcement for %s, got %+v", logID, entries)
+	}
+
+	SetAnnounceLevel(zapcore.DebugLevel)
+	_, cancel = GetCtx("debug")
+	defer cancel()
+	if got := observed.FilterLevel(zapcore.DebugLevel); len(got) != 1 {
+		t.Errorf("Expected debug announcement, got %+v", got)
+	}
+}
+
+func TestAnnouncerOptions(t *testing.T) {
+	observed := logger.NewObserved(t)
+	defer SetAnnounceLevel(zapcore.InfoLevel)
+
+	var hooked []string
+	hook := func(ctx context.Context, logID string) {
+		hooked = append(hooked, logID)
+	}
+
+	_, cancel := GetCtx("hook", WithAnnouncer(hook))
+	defer cancel()
+	_, cancel = GetCtx("silent", WithoutAnnounce())
+	defer cancel()
+
+	SetAnnouncer(nil)
+	_, cancel = GetCtx("disabled")
+	defer cancel()
+
+	if len(hooked) != 1 {
+		t.Errorf("Expected hook to be called once, got %v", hooked)
+	}
+	if observed.Len() != 0 {
+		t.Errorf("Expected no logger announcements, got %+v", observed.All())
+	}
+}
@@ -0,0 +1,279 @@
+package ctx
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/marcuspeh/go-tools/logger"
+)
+
+const (
+	BaggageHeader = "baggage"
+
+	// BaggageKey is the logger field holding the baggage entries selected with
+	// LogBaggageKeys.
+	BaggageKey = "baggage"
+
+	MaxBaggageEntries = 64
+	MaxBaggageBytes   = 8192
+)
+
+var (
+	ErrInvalidBaggageKey = errors.New("invalid baggage key")
+	ErrBaggageLimit      = errors.New("baggage limit exceeded")
+)
+
+type baggageCtxKey struct{}
+
+var loggedBaggage atomic.Pointer[[]string]
+
+func init() {
+	logger.RegisterExtractor(func(ctx context.Context) []zap.Field {
+		names := loggedBaggage.Load()
+		if names == nil {
+			return nil
+		}
+
+		b := BaggageFrom(ctx)
+		logged := make(baggageFields, 0, len(*names))
+		for _, name := range *names {
+			if val, ok := b.Value(name); ok {
+				logged = append(logged, [2]string{name, val})
+			}
+		}
+		if len(logged) == 0 {
+			return nil
+		}
+		return []zap.Field{zap.Object(BaggageKey, logged)}
+	})
+}
+
+// Key is a typed baggage key. Values are stored in their encoded form so
+// they can be propagated as a header.
+type Key[T any] struct {
+	name   string
+	encode func(T) string
+	decode func(string) (T, error)
+}
+
+func NewKey[T any](name string, encode func(T) string, decode func(string) (T, error)) Key[T] {
+	return Key[T]{name: name, encode: encode, decode: decode}
+}
+
+func StringKey(name string) Key[string] {
+	return NewKey(name, func(s string) string { return s }, func(s string) (string, error) { return s, nil })
+}
+
+func IntKey(name string) Key[int] {
+	return NewKey(name, strconv.Itoa, strconv.Atoi)
+}
+
+func BoolKey(name string) Key[bool] {
+	return NewKey(name, strconv.FormatBool, strconv.ParseBool)
+}
+
+func (k Key[T]) Name() string {
+	return k.name
+}
+
+// Baggage is an immutable set of encoded entries. The zero value is empty.
+type Baggage struct {
+	entries map[string]string
+	// size is the length of String plus one, tracked so that limits can be
+	// checked without re-encoding every entry.
+	size int
+}
+
+func (b Baggage) Len() int {
+	return len(b.entries)
+}
+
+func (b Baggage) Value(name string) (string, bool) {
+	val, ok := b.entries[name]
+	return val, ok
+}
+
+// String formats b as a baggage header value with entries sorted by name.
+func (b Baggage) String() string {
+	names := make([]string, 0, len(b.entries))
+	for name := range b.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	members := make([]string, len(names))
+	for i, name := range names {
+		members[i] = name + "=" + url.PathEscape(b.entries[name])
+	}
+	return strings.Join(members, ",")
+}
+
+// memberSize is the encoded length of an entry including its separator.
+func memberSize(name, val string) int {
+	return len(name) + len(url.PathEscape(val)) + 2
+}
+
+// fits reports whether setting name to val keeps b within the size limits.
+func (b Baggage) fits(name, val string) (int, bool) {
+	size := b.size + memberSize(name, val)
+	count := len(b.entries) + 1
+	if old, ok := b.entries[name]; ok {
+		size -= memberSize(name, old)
+		count--
+	}
+	return size, count <= MaxBaggageEntries && size-1 <= MaxBaggageBytes
+}
+
+func (b Baggage) with(name, val string) (Baggage, error) {
+	if !validBaggageKey(name) {
+		return b, ErrInvalidBaggageKey
+	}
+	size, ok := b.fits(name, val)
+	if !ok {
+		return b, ErrBaggageLimit
+	}
+
+	entries := make(map[string]string, len(b.entries)+1)
+	for k, v := range b.entries {
+		entries[k] = v
+	}
+	entries[name] = val
+	return Baggage{entries: entries, size: size}, nil
+}
+
+func (b Baggage) without(name string) Baggage {
+	old, ok := b.entries[name]
+	if !ok {
+		return b
+	}
+
+	entries := make(map[string]string, len(b.entries))
+	for k, v := range b.entries {
+		if k != name {
+			entries[k] = v
+		}
+	}
+	return Baggage{entries: entries, size: b.size - memberSize(name, old)}
+}
+
+// ParseBaggage parses a baggage header value. Malformed members and members
+// past the size limits are dropped, and member properties are ignored. Input
+// beyond MaxBaggageBytes is not looked at.
+func ParseBaggage(s string) Baggage {
+	if len(s) > MaxBaggageBytes {
+		// Drop the member cut off at the limit rather than keep part of it.
+		s = s[:MaxBaggageBytes]
+		if i := strings.LastIndexByte(s, ','); i >= 0 {
+			s = s[:i]
+		} else {
+			s = ""
+		}
+	}
+
+	// b is built in place, which is safe until it is returned.
+	b := Baggage{entries: map[string]string{}}
+	for s != "" && len(b.entries) < MaxBaggageEntries {
+		var member string
+		member, s, _ = strings.Cut(s, ",")
+		member, _, _ = strings.Cut(member, ";")
+
+		name, val, ok := strings.Cut(member, "=")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		val, err := url.PathUnescape(strings.TrimSpace(val))
+		if err != nil || !validBaggageKey(name) {
+			continue
+		}
+		if size, ok := b.fits(name, val); ok {
+			b.entries[name] = val
+			b.size = size
+		}
+	}
+	return b
+}
+
+func validBaggageKey(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c <= ' ' || c > '~' || strings.IndexByte(`"(),/:;<=>?@[\]{}`, c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func WithBaggage(ctx context.Context, b Baggage) context.Context {
+	return context.WithValue(ctx, baggageCtxKey{}, b)
+}
+
+func BaggageFrom(ctx context.Context) Baggage {
+	b, _ := ctx.Value(baggageCtxKey{}).(Baggage)
+	return b
+}
+
+// Set returns a context whose baggage has key set to val. It fails with
+// ErrBaggageLimit if the entry would not fit in the baggage header.
+func Set[T any](ctx context.Context, key Key[T], val T) (context.Context, error) {
+	b, err := BaggageFrom(ctx).with(key.name, key.encode(val))
+	if err != nil {
+		return ctx, err
+	}
+	return WithBaggage(ctx, b), nil
+}
+
+// Get returns the value of key, reporting false if it is missing or cannot be
+// decoded.
+func Get[T any](ctx context.Context, key Key[T]) (T, bool) {
+	var zero T
+
+	raw, ok := BaggageFrom(ctx).Value(key.name)
+	if !ok {
+		return zero, false
+	}
+	val, err := key.decode(raw)
+	if err != nil {
+		return zero, false
+	}
+	return val, true
+}
+
+func Delete[T any](ctx context.Context, key Key[T]) context.Context {
+	b := BaggageFrom(ctx)
+	if _, ok := b.Value(key.name); !ok {
+		return ctx
+	}
+	return WithBaggage(ctx, b.without(key.name))
+}
+
+// LogBaggageKeys adds the named baggage entries to every log call under the
+// BaggageKey field. It replaces any previously selected names.
+func LogBaggageKeys(names ...string) {
+	if len(names) == 0 {
+		loggedBaggage.Store(nil)
+		return
+	}
+	names = append([]string{}, names...)
+	loggedBaggage.Store(&names)
+}
+
+type baggageFields [][2]string
+
+func (f baggageFields) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, kv := range f {
+		enc.AddString(kv[0], kv[1])
+	}
+	return nil
+}
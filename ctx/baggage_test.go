@@ -0,0 +1,180 @@
+package ctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcuspeh/go-tools/logger"
+)
+
+var (
+	tenantKey  = StringKey("tenant")
+	userKey    = IntKey("user_id")
+	betaKey    = BoolKey("beta")
+	invalidKey = StringKey("bad key")
+)
+
+func TestBaggageSetGet(t *testing.T) {
+	root := context.Background()
+
+	ctx, err := Set(root, tenantKey, "acme, inc")
+	if err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	ctx, _ = Set(ctx, userKey, 42)
+	child, _ := Set(ctx, betaKey, true)
+
+	if tenant, ok := Get(child, tenantKey); !ok || tenant != "acme, inc" {
+		t.Errorf("Expected tenant, got %q", tenant)
+	}
+	if user, ok := Get(child, userKey); !ok || user != 42 {
+		t.Errorf("Expected user 42, got %d", user)
+	}
+	if _, ok := Get(ctx, betaKey); ok {
+		t.Error("Expected parent baggage to be unchanged")
+	}
+	if _, ok := Get(root, tenantKey); ok {
+		t.Error("Expected empty baggage on root")
+	}
+
+	deleted := Delete(child, userKey)
+	if _, ok := Get(deleted, userKey); ok {
+		t.Error("Expected user to be deleted")
+	}
+	if _, ok := Get(child, userKey); !ok {
+		t.Error("Expected delete to leave the original intact")
+	}
+
+	if _, ok := Get(child, IntKey("tenant")); ok {
+		t.Error("Expected undecodable value to be reported missing")
+	}
+	if _, err := Set(root, invalidKey, "x"); !errors.Is(err, ErrInvalidBaggageKey) {
+		t.Errorf("Expected ErrInvalidBaggageKey, got %v", err)
+	}
+}
+
+func TestBaggageLimits(t *testing.T) {
+	ctx := context.Background()
+	for i := 0; i < MaxBaggageEntries; i++ {
+		var err error
+		if ctx, err = Set(ctx, IntKey("k"+strings.Repeat("x", i)), i); err != nil {
+			t.Fatalf("Set %d failed: %v", i, err)
+		}
+	}
+	if _, err := Set(ctx, tenantKey, "one too many"); !errors.Is(err, ErrBaggageLimit) {
+		t.Errorf("Expected entry limit, got %v", err)
+	}
+
+	if _, err := Set(context.Background(), tenantKey, strings.Repeat("x", MaxBaggageBytes)); !errors.Is(err, ErrBaggageLimit) {
+		t.Errorf("Expected size limit, got %v", err)
+	}
+}
+
+func TestParseBaggage(t *testing.T) {
+	b := ParseBaggage("tenant=acme%2C%20inc;prop=1, user_id = 42 ,broken,bad key=1,beta=true")
+
+	if b.Len() != 3 {
+		t.Fatalf("Expected 3 entries, got %d (%s)", b.Len(), b)
+	}
+	if got := b.String(); got != "beta=true,tenant=acme%2C%20inc,user_id=42" {
+		t.Errorf("Unexpected header %q", got)
+	}
+
+	ctx := WithBaggage(context.Background(), b)
+	if tenant, _ := Get(ctx, tenantKey); tenant != "acme, inc" {
+		t.Errorf("Expected decoded tenant, got %q", tenant)
+	}
+}
+
+func TestBaggageLogged(t *testing.T) {
+	observed := logger.NewObserved(t)
+	LogBaggageKeys(tenantKey.Name(), betaKey.Name())
+	defer LogBaggageKeys()
+
+	ctx, _ := Set(context.Background(), tenantKey, "acme")
+	ctx, _ = Set(ctx, userKey, 7)
+	logger.Info(ctx, "with baggage")
+	logger.Info(context.Background(), "without baggage")
+
+	entries := observed.All()
+	logged, ok := entries[0].Fields[BaggageKey].(map[string]interface{})
+	if !ok || len(logged) != 1 || logged["tenant"] != "acme" {
+		t.Errorf("Expected only tenant to be logged, got %v", entries[0].Fields)
+	}
+	if _, ok := entries[1].Fields[BaggageKey]; ok {
+		t.Errorf("Expected no baggage field, got %v", entries[1].Fields)
+	}
+}
+
+func TestBaggagePropagation(t *testing.T) {
+	var got context.Context
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Context()
+	}), WithoutAnnounce())
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(BaggageHeader, "tenant=acme,beta=true")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if tenant, _ := Get(got, tenantKey); tenant != "acme" {
+		t.Errorf("Expected tenant from header, got %q", tenant)
+	}
+
+	out := http.Header{}
+	InjectHeaders(got, out)
+	if out.Get(BaggageHeader) != "beta=true,tenant=acme" {
+		t.Errorf("Expected baggage to be injected, got %v", out)
+	}
+
+	detached, cancel := Detach(got, WithCarriers(CarryBaggage))
+	defer cancel()
+	if beta, _ := Get(detached, betaKey); !beta {
+		t.Error("Expected baggage to be carried by Detach")
+	}
+}
+
+func TestParseBaggageOversized(t *testing.T) {
+	var header strings.Builder
+	for i := 0; i < MaxBaggageEntries-1; i++ {
+		fmt.Fprintf(&header, "k%02d=%s,", i, strings.Repeat("v", 120))
+	}
+	for header.Len() < 1<<20 {
+		header.WriteString("k00=x,")
+	}
+
+	start := time.Now()
+	b := ParseBaggage(header.String())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected oversized header to parse quickly, took %v", elapsed)
+	}
+
+	if b.Len() != MaxBaggageEntries-1 {
+		t.Errorf("Expected %d entries, got %d", MaxBaggageEntries-1, b.Len())
+	}
+	if encoded := b.String(); len(encoded) > MaxBaggageBytes || len(encoded) != b.size-1 {
+		t.Errorf("Expected encoded size %d within limits, got %d", b.size-1, len(encoded))
+	}
+
+	if b := ParseBaggage("tenant=" + strings.Repeat("a", 9000)); b.Len() != 0 {
+		t.Errorf("Expected cut off member to be dropped, got %d entries", b.Len())
+	}
+
+	cut := "user_id=42,tenant=" + strings.Repeat("a", MaxBaggageBytes)
+	if b := ParseBaggage(cut); b.Len() != 1 {
+		t.Errorf("Expected only the complete member, got %s", b)
+	}
+
+	many := strings.Repeat("a=1,", 10) + strings.Repeat("x", 10)
+	for i := 0; i < 2*MaxBaggageEntries; i++ {
+		many += fmt.Sprintf(",n%d=1", i)
+	}
+	if b := ParseBaggage(many); b.Len() != MaxBaggageEntries {
+		t.Errorf("Expected parsing to stop at %d entries, got %d", MaxBaggageEntries, b.Len())
+	}
+}
@@ -2,21 +2,35 @@ package ctx
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/marcuspeh/go-tools/logger"
 )
 
-func GetCtx(postfix string) (context.Context, context.CancelFunc) {
-	ctx, cancel := context.WithCancel(context.Background())
-	logID := fmt.Sprintf("%s_%s_%v",
-		time.Now().UTC().Format(time.DateOnly),
-		time.Now().UTC().Format(time.TimeOnly),
-		postfix,
-	)
-	fmt.Println("LogID: ", logID)
+func GetCtx(postfix string, opts ...Option) (context.Context, context.CancelFunc) {
+	return GetCtxFrom(context.Background(), postfix, opts...)
+}
+
+// GetCtxFrom derives a cancellable context from parent, keeping the parent's
+// log ID if it has one and generating a new one otherwise.
+func GetCtxFrom(parent context.Context, postfix string, opts ...Option) (context.Context, context.CancelFunc) {
+	o := newOptions(opts...)
+
+	ctx, cancel := o.derive(parent)
+	if _, ok := LogIDFrom(ctx); ok {
+		return ctx, cancel
+	}
+
+	logID := o.newLogID(postfix)
+	ctx = WithLogID(ctx, logID)
+	o.announce(ctx, logID)
 
-	ctx = context.WithValue(ctx, logger.LogIDKey, logID)
 	return ctx, cancel
 }
+
+func WithLogID(ctx context.Context, logID string) context.Context {
+	return logger.ContextWithLogID(ctx, logID)
+}
+
+func LogIDFrom(ctx context.Context) (string, bool) {
+	return logger.LogIDFromContext(ctx)
+}
@@ -1,6 +1,8 @@
 package ctx
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -17,14 +19,9 @@ func TestGetCtx(t *testing.T) {
 		t.Fatal("Expected context, got nil")
 	}
 
-	logID := ctx.Value(logger.LogIDKey)
-	if logID == nil {
-		t.Fatal("Expected LogID in context, got nil")
-	}
-
-	logIDStr, ok := logID.(string)
+	logIDStr, ok := LogIDFrom(ctx)
 	if !ok {
-		t.Fatal("Expected LogID to be string")
+		t.Fatal("Expected LogID in context")
 	}
 
 	if !strings.Contains(logIDStr, postfix) {
@@ -64,3 +61,93 @@ func TestGetCtx(t *testing.T) {
 		t.Fatal("Context should be done after cancel")
 	}
 }
+
+func TestWithLogID(t *testing.T) {
+	ctx := WithLogID(context.Background(), "custom-id")
+
+	logID, ok := LogIDFrom(ctx)
+	if !ok || logID != "custom-id" {
+		t.Errorf("Expected custom-id, got %q (ok=%v)", logID, ok)
+	}
+
+	if _, ok := LogIDFrom(context.Background()); ok {
+		t.Error("Expected no LogID in empty context")
+	}
+
+	if ctx.Value(logger.LogIDKey) != nil {
+		t.Error("Expected LogID not to be stored under the plain string key")
+	}
+}
+
+func TestGetCtxFrom(t *testing.T) {
+	parent := WithLogID(context.Background(), "parent-id")
+	ctx, cancel := GetCtxFrom(parent, "child")
+	defer cancel()
+
+	if logID, _ := LogIDFrom(ctx); logID != "parent-id" {
+		t.Errorf("Expected parent log ID to be preserved, got %s", logID)
+	}
+
+	parentCtx, parentCancel := context.WithCancel(context.Background())
+	ctx, cancel = GetCtxFrom(parentCtx, "child")
+	defer cancel()
+
+	if logID, _ := LogIDFrom(ctx); !strings.Contains(logID, "child") {
+		t.Errorf("Expected new log ID with postfix, got %s", logID)
+	}
+
+	parentCancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected child to be done after parent cancel")
+	}
+}
+
+func TestGetCtxTimeoutAndCause(t *testing.T) {
+	errShutdown := errors.New("shutdown")
+
+	ctx, cancel := GetCtx("timeout", WithTimeout(10*time.Millisecond), WithCause(errShutdown))
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); !ok {
+		t.Fatal("Expected deadline to be set")
+	}
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) || !errors.Is(context.Cause(ctx), errShutdown) {
+		t.Errorf("Expected deadline exceeded with cause, got %v / %v", ctx.Err(), context.Cause(ctx))
+	}
+
+	ctx, cancel = GetCtx("cancel", WithCause(errShutdown))
+	cancel()
+	if !errors.Is(ctx.Err(), context.Canceled) || !errors.Is(context.Cause(ctx), errShutdown) {
+		t.Errorf("Expected canceled with cause, got %v / %v", ctx.Err(), context.Cause(ctx))
+	}
+
+	deadline := time.Now().Add(time.Hour)
+	ctx, cancel = GetCtx("deadline", WithDeadline(deadline), WithTimeout(2*time.Hour))
+	defer cancel()
+	if got, _ := ctx.Deadline(); !got.Equal(deadline) {
+		t.Errorf("Expected earliest deadline %v, got %v", deadline, got)
+	}
+}
+
+func TestGetCtxReusedTimeout(t *testing.T) {
+	opts := []Option{WithTimeout(time.Hour), WithoutAnnounce()}
+	time.Sleep(20 * time.Millisecond)
+
+	before := time.Now()
+	ctx, cancel := GetCtx("reused", opts...)
+	defer cancel()
+
+	deadline, _ := ctx.Deadline()
+	if deadline.Before(before.Add(time.Hour)) {
+		t.Errorf("Expected deadline relative to creation, got %v", deadline)
+	}
+
+	detached, cancel := Detach(ctx, opts...)
+	defer cancel()
+	if deadline, _ := detached.Deadline(); deadline.Before(before.Add(time.Hour)) {
+		t.Errorf("Expected Detach deadline relative to creation, got %v", deadline)
+	}
+}
@@ -0,0 +1,79 @@
+package ctx
+
+import (
+	"context"
+
+	"github.com/marcuspeh/go-tools/logger"
+)
+
+// Carrier copies a value from src into dst for Detach.
+type Carrier func(dst, src context.Context) context.Context
+
+var (
+	CarryLogID Carrier = func(dst, src context.Context) context.Context {
+		if logID, ok := LogIDFrom(src); ok {
+			return WithLogID(dst, logID)
+		}
+		return dst
+	}
+
+	CarrySpan Carrier = func(dst, src context.Context) context.Context {
+		if sc, ok := SpanContextFrom(src); ok {
+			return WithSpanContext(dst, sc)
+		}
+		return dst
+	}
+
+	CarryBaggage Carrier = func(dst, src context.Context) context.Context {
+		if b := BaggageFrom(src); b.Len() > 0 {
+			return WithBaggage(dst, b)
+		}
+		return dst
+	}
+
+	// CarryFields copies the fields attached with logger.WithFields.
+	CarryFields Carrier = func(dst, src context.Context) context.Context {
+		if fields := logger.FieldsFrom(src); len(fields) > 0 {
+			return logger.WithFields(dst, fields...)
+		}
+		return dst
+	}
+
+	// CarryLogger copies a logger attached with logger.NewContext.
+	CarryLogger Carrier = func(dst, src context.Context) context.Context {
+		// FromContext falls back to the default logger, which dst resolves
+		// on its own.
+		if l := logger.FromContext(src); l != logger.Default() {
+			return logger.NewContext(dst, l)
+		}
+		return dst
+	}
+)
+
+// CarryKey copies the value stored under key.
+func CarryKey(key any) Carrier {
+	return func(dst, src context.Context) context.Context {
+		if val := src.Value(key); val != nil {
+			return context.WithValue(dst, key, val)
+		}
+		return dst
+	}
+}
+
+// Detach returns a context that outlives parent, for background work started
+// on behalf of a request. It keeps every value of parent but none of its
+// cancellation or deadline, and has its own cancel, deadline (see WithTimeout
+// and WithDeadline) and cause. With WithCarriers only the carried values are
+// kept.
+func Detach(parent context.Context, opts ...Option) (context.Context, context.CancelFunc) {
+	o := newOptions(opts...)
+
+	base := context.WithoutCancel(parent)
+	if o.carriers != nil {
+		base = context.Background()
+		for _, carry := range o.carriers {
+			base = carry(base, parent)
+		}
+	}
+	return o.derive(base)
+}
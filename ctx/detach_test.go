@@ -0,0 +1,110 @@
+package ctx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marcuspeh/go-tools/logger"
+)
+
+type detachTestKey struct{}
+
+func detachParent(t *testing.T) (context.Context, *logger.Logger) {
+	t.Helper()
+
+	l := logger.NewLogger(logger.WithoutFile())
+	ctx, cancel := GetCtx("request", WithoutAnnounce())
+	t.Cleanup(cancel)
+
+	ctx, _ = StartSpan(ctx)
+	ctx = logger.WithFields(ctx, zap.String("user", "alice"))
+	ctx = logger.NewContext(ctx, l)
+	ctx = context.WithValue(ctx, detachTestKey{}, "kept")
+	return ctx, l
+}
+
+func TestDetach(t *testing.T) {
+	parent, l := detachParent(t)
+	parentCtx, cancelParent := context.WithCancel(parent)
+
+	ctx, cancel := Detach(parentCtx)
+	defer cancel()
+	cancelParent()
+
+	if ctx.Err() != nil {
+		t.Fatalf("Expected detached context to survive parent, got %v", ctx.Err())
+	}
+	if got, _ := LogIDFrom(ctx); got != mustLogID(t, parent) {
+		t.Errorf("Expected log ID to be kept, got %q", got)
+	}
+	if ctx.Value(detachTestKey{}) != "kept" || logger.FromContext(ctx) != l {
+		t.Error("Expected all values to be kept")
+	}
+
+	cancel()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("Expected own cancellation, got %v", ctx.Err())
+	}
+}
+
+func TestDetachCarriers(t *testing.T) {
+	parent, _ := detachParent(t)
+
+	ctx, cancel := Detach(parent, WithCarriers(CarryLogID, CarrySpan, CarryFields))
+	defer cancel()
+
+	if got, _ := LogIDFrom(ctx); got != mustLogID(t, parent) {
+		t.Errorf("Expected log ID to be carried, got %q", got)
+	}
+	want, _ := SpanContextFrom(parent)
+	if got, _ := SpanContextFrom(ctx); got != want {
+		t.Errorf("Expected span %+v, got %+v", want, got)
+	}
+	if fields := logger.FieldsFrom(ctx); len(fields) != 1 || fields[0].Key != "user" {
+		t.Errorf("Expected logger fields to be carried, got %v", fields)
+	}
+	if ctx.Value(detachTestKey{}) != nil || logger.FromContext(ctx) != logger.Default() {
+		t.Error("Expected uncarried values to be dropped")
+	}
+
+	ctx, cancel = Detach(parent, WithCarriers(CarryKey(detachTestKey{}), CarryLogger))
+	defer cancel()
+	if ctx.Value(detachTestKey{}) != "kept" || logger.FromContext(ctx) == logger.Default() {
+		t.Error("Expected key and logger to be carried")
+	}
+	if _, ok := LogIDFrom(ctx); ok {
+		t.Error("Expected log ID to be dropped")
+	}
+}
+
+func TestDetachTimeout(t *testing.T) {
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelParent()
+
+	cause := errors.New("refresh took too long")
+	ctx, cancel := Detach(parent, WithTimeout(10*time.Millisecond), WithCause(cause))
+	defer cancel()
+
+	if deadline, _ := ctx.Deadline(); time.Until(deadline) > time.Minute {
+		t.Errorf("Expected own deadline, got %v", deadline)
+	}
+
+	<-ctx.Done()
+	if !errors.Is(context.Cause(ctx), cause) {
+		t.Errorf("Expected cause %v, got %v", cause, context.Cause(ctx))
+	}
+}
+
+func mustLogID(t *testing.T, ctx context.Context) string {
+	t.Helper()
+
+	logID, ok := LogIDFrom(ctx)
+	if !ok {
+		t.Fatal("Expected log ID in context")
+	}
+	return logID
+}
@@ -0,0 +1,98 @@
+package ctx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	DefaultLogIDHeader = "X-Log-ID"
+
+	httpPostfix = "http"
+
+	// maxHeaderLogIDLen bounds log IDs accepted from incoming requests.
+	maxHeaderLogIDLen = 128
+)
+
+var logIDHeader atomic.Pointer[string]
+
+// SetLogIDHeader changes the header used to propagate log IDs. An empty name
+// restores DefaultLogIDHeader.
+func SetLogIDHeader(name string) {
+	if name == "" {
+		logIDHeader.Store(nil)
+		return
+	}
+	name = http.CanonicalHeaderKey(name)
+	logIDHeader.Store(&name)
+}
+
+func LogIDHeader() string {
+	if name := logIDHeader.Load(); name != nil {
+		return *name
+	}
+	return DefaultLogIDHeader
+}
+
+// Middleware derives each request's context with GetCtxFrom, reusing the log
+// ID, trace context and baggage from the request headers when present, and
+// echoes the log ID on the response. Each request runs in its own span.
+func Middleware(next http.Handler, opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, _ := StartSpan(ExtractHeaders(r.Context(), r.Header))
+
+		ctx, cancel := GetCtxFrom(ctx, httpPostfix, opts...)
+		defer cancel()
+
+		if logID, ok := LogIDFrom(ctx); ok {
+			w.Header().Set(LogIDHeader(), logID)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ExtractHeaders returns ctx with the log ID, trace context and baggage found
+// in h. Malformed values are ignored.
+func ExtractHeaders(ctx context.Context, h http.Header) context.Context {
+	if logID := h.Get(LogIDHeader()); validHeaderLogID(logID) {
+		ctx = WithLogID(ctx, logID)
+	}
+	if sc, err := ParseTraceparent(h.Get(TraceparentHeader)); err == nil {
+		sc.State = ParseTracestate(strings.Join(h.Values(TracestateHeader), ","))
+		ctx = WithSpanContext(ctx, sc)
+	}
+	if b := ParseBaggage(strings.Join(h.Values(BaggageHeader), ",")); b.Len() > 0 {
+		ctx = WithBaggage(ctx, b)
+	}
+	return ctx
+}
+
+// InjectHeaders sets the propagation headers for ctx on h.
+func InjectHeaders(ctx context.Context, h http.Header) {
+	if logID, ok := LogIDFrom(ctx); ok {
+		h.Set(LogIDHeader(), logID)
+	}
+	if sc, ok := SpanContextFrom(ctx); ok {
+		h.Set(TraceparentHeader, sc.Traceparent())
+		if sc.State != "" {
+			h.Set(TracestateHeader, sc.State)
+		}
+	}
+	if b := BaggageFrom(ctx); b.Len() > 0 {
+		h.Set(BaggageHeader, b.String())
+	}
+}
+
+func validHeaderLogID(logID string) bool {
+	if logID == "" || len(logID) > maxHeaderLogIDLen {
+		return false
+	}
+	for i := 0; i < len(logID); i++ {
+		if logID[i] <= ' ' || logID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
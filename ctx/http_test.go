@@ -0,0 +1,62 @@
+package ctx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	var got string
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = LogIDFrom(r.Context())
+	}), WithoutAnnounce())
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(DefaultLogIDHeader, "upstream-id")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got != "upstream-id" {
+		t.Errorf("Expected upstream log ID, got %q", got)
+	}
+	if echoed := rec.Header().Get(DefaultLogIDHeader); echoed != "upstream-id" {
+		t.Errorf("Expected echoed log ID, got %q", echoed)
+	}
+
+	for _, incoming := range []string{"", "has space", strings.Repeat("a", maxHeaderLogIDLen+1)} {
+		req = httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(DefaultLogIDHeader, incoming)
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if got == incoming || !strings.Contains(got, "_"+httpPostfix) {
+			t.Errorf("Expected generated log ID for %q, got %q", incoming, got)
+		}
+		if echoed := rec.Header().Get(DefaultLogIDHeader); echoed != got {
+			t.Errorf("Expected echoed log ID %q, got %q", got, echoed)
+		}
+	}
+}
+
+func TestSetLogIDHeader(t *testing.T) {
+	SetLogIDHeader("x-request-id")
+	defer SetLogIDHeader("")
+
+	if LogIDHeader() != "X-Request-Id" {
+		t.Errorf("Expected canonical header, got %q", LogIDHeader())
+	}
+
+	h := http.Header{}
+	InjectHeaders(WithLogID(context.Background(), "abc"), h)
+	if h.Get("X-Request-Id") != "abc" || h.Get(DefaultLogIDHeader) != "" {
+		t.Errorf("Expected log ID under custom header, got %v", h)
+	}
+
+	SetLogIDHeader("")
+	if LogIDHeader() != DefaultLogIDHeader {
+		t.Errorf("Expected default header, got %q", LogIDHeader())
+	}
+}
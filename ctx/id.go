@@ -0,0 +1,263 @@
+package ctx
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// snowflakeEpoch is 2020-01-01T00:00:00Z in unix milliseconds.
+const snowflakeEpoch = 1577836800000
+
+type IDGenerator interface {
+	// NewID returns a log ID that is unique within the process. Generators
+	// other than the timestamp one append postfix after an underscore.
+	NewID(postfix string) string
+}
+
+type IDGeneratorFunc func(postfix string) string
+
+func (f IDGeneratorFunc) NewID(postfix string) string {
+	return f(postfix)
+}
+
+var (
+	TimestampIDs IDGenerator = NewTimestampGenerator()
+	UUIDv4IDs    IDGenerator = NewUUIDv4Generator()
+	UUIDv7IDs    IDGenerator = NewUUIDv7Generator()
+	ULIDIDs      IDGenerator = NewULIDGenerator()
+	SnowflakeIDs IDGenerator = NewSnowflakeGenerator(defaultNodeID())
+
+	idGenerator atomic.Pointer[IDGenerator]
+)
+
+func init() {
+	SetIDGenerator(TimestampIDs)
+}
+
+// SetIDGenerator changes the generator used by GetCtx when none is passed.
+func SetIDGenerator(g IDGenerator) {
+	if g == nil {
+		g = TimestampIDs
+	}
+	idGenerator.Store(&g)
+}
+
+func NewLogID(postfix string) string {
+	return (*idGenerator.Load()).NewID(postfix)
+}
+
+func withPostfix(id, postfix string) string {
+	if postfix == "" {
+		return id
+	}
+	return id + "_" + postfix
+}
+
+// TimestampGenerator produces "<date>_<time>_<postfix>" IDs at second
+// resolution, adding a "_<n>" sequence for repeated IDs within a second.
+type TimestampGenerator struct {
+	mu     sync.Mutex
+	second int64
+	seq    uint64
+}
+
+func NewTimestampGenerator() *TimestampGenerator {
+	return &TimestampGenerator{}
+}
+
+func (g *TimestampGenerator) NewID(postfix string) string {
+	g.mu.Lock()
+	// Reading the clock under the lock and never moving back keeps IDs
+	// unique when callers race or the wall clock steps backwards.
+	second := max(time.Now().Unix(), g.second)
+	if second != g.second {
+		g.second = second
+		g.seq = 0
+	} else {
+		g.seq++
+	}
+	seq := g.seq
+	g.mu.Unlock()
+
+	now := time.Unix(second, 0).UTC()
+	id := fmt.Sprintf("%s_%s_%v", now.Format(time.DateOnly), now.Format(time.TimeOnly), postfix)
+	if seq > 0 {
+		id += "_" + strconv.FormatUint(seq, 10)
+	}
+	return id
+}
+
+type UUIDv4Generator struct{}
+
+func NewUUIDv4Generator() *UUIDv4Generator {
+	return &UUIDv4Generator{}
+}
+
+func (g *UUIDv4Generator) NewID(postfix string) string {
+	var uuid [16]byte
+	_, _ = rand.Read(uuid[:])
+	uuid[6] = uuid[6]&0x0f | 0x40
+	uuid[8] = uuid[8]&0x3f | 0x80
+	return withPostfix(formatUUID(uuid), postfix)
+}
+
+// UUIDv7Generator produces time-ordered UUIDs, using the 12 bit rand_a field
+// as a counter so IDs within the same millisecond stay unique and ordered.
+type UUIDv7Generator struct {
+	mu     sync.Mutex
+	lastMs int64
+	seq    uint16
+}
+
+func NewUUIDv7Generator() *UUIDv7Generator {
+	return &UUIDv7Generator{}
+}
+
+func (g *UUIDv7Generator) NewID(postfix string) string {
+	var uuid [16]byte
+	_, _ = rand.Read(uuid[:])
+
+	g.mu.Lock()
+	ms := time.Now().UnixMilli()
+	if ms > g.lastMs {
+		g.lastMs = ms
+		g.seq = binary.BigEndian.Uint16(uuid[6:8]) & 0x07ff
+	} else {
+		g.seq++
+		if g.seq > 0x0fff {
+			g.lastMs++
+			g.seq = 0
+		}
+	}
+	ms, seq := g.lastMs, g.seq
+	g.mu.Unlock()
+
+	uuid[0] = byte(ms >> 40)
+	uuid[1] = byte(ms >> 32)
+	uuid[2] = byte(ms >> 24)
+	uuid[3] = byte(ms >> 16)
+	uuid[4] = byte(ms >> 8)
+	uuid[5] = byte(ms)
+	uuid[6] = 0x70 | byte(seq>>8)
+	uuid[7] = byte(seq)
+	uuid[8] = uuid[8]&0x3f | 0x80
+	return withPostfix(formatUUID(uuid), postfix)
+}
+
+func formatUUID(uuid [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
+
+// ULIDGenerator produces monotonic ULIDs: IDs within the same millisecond
+// increment the random part instead of drawing a new one.
+type ULIDGenerator struct {
+	mu      sync.Mutex
+	lastMs  int64
+	entropy [10]byte
+}
+
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{}
+}
+
+func (g *ULIDGenerator) NewID(postfix string) string {
+	g.mu.Lock()
+	ms := time.Now().UnixMilli()
+	if ms > g.lastMs {
+		g.lastMs = ms
+		_, _ = rand.Read(g.entropy[:])
+	} else if !incrementBytes(g.entropy[:]) {
+		g.lastMs++
+		_, _ = rand.Read(g.entropy[:])
+	}
+
+	var ulid [16]byte
+	ms = g.lastMs
+	ulid[0] = byte(ms >> 40)
+	ulid[1] = byte(ms >> 32)
+	ulid[2] = byte(ms >> 24)
+	ulid[3] = byte(ms >> 16)
+	ulid[4] = byte(ms >> 8)
+	ulid[5] = byte(ms)
+	copy(ulid[6:], g.entropy[:])
+	g.mu.Unlock()
+
+	return withPostfix(encodeCrockford(ulid), postfix)
+}
+
+// incrementBytes adds one to b as a big-endian number and reports false on
+// overflow.
+func incrementBytes(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeCrockford(id [16]byte) string {
+	hi := binary.BigEndian.Uint64(id[0:8])
+	lo := binary.BigEndian.Uint64(id[8:16])
+
+	// 128 bits as 26 characters of 5 bits, the first holding the top 3 bits.
+	out := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out)
+}
+
+// SnowflakeGenerator produces 63 bit IDs from a millisecond timestamp, a
+// 10 bit node ID and a 12 bit per-millisecond sequence.
+type SnowflakeGenerator struct {
+	mu     sync.Mutex
+	node   int64
+	lastMs int64
+	seq    int64
+}
+
+func NewSnowflakeGenerator(node int64) *SnowflakeGenerator {
+	return &SnowflakeGenerator{node: node & 0x3ff}
+}
+
+func (g *SnowflakeGenerator) NewID(postfix string) string {
+	g.mu.Lock()
+	ms := time.Now().UnixMilli() - snowflakeEpoch
+	if ms < g.lastMs {
+		ms = g.lastMs
+	}
+	if ms == g.lastMs {
+		g.seq = (g.seq + 1) & 0xfff
+		if g.seq == 0 {
+			// Sequence exhausted for this millisecond; borrow the next one.
+			ms++
+		}
+	} else {
+		g.seq = 0
+	}
+	g.lastMs = ms
+	id := ms<<22 | g.node<<12 | g.seq
+	g.mu.Unlock()
+
+	return withPostfix(strconv.FormatInt(id, 10), postfix)
+}
+
+func defaultNodeID() int64 {
+	host, _ := os.Hostname()
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s/%d", host, os.Getpid())
+	return int64(h.Sum32() & 0x3ff)
+}
@@ -0,0 +1,166 @@
+package ctx
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func generateConcurrently(g IDGenerator, goroutines, perGoroutine int) []string {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ids := make([]string, 0, goroutines*perGoroutine)
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := make([]string, 0, perGoroutine)
+			for j := 0; j < perGoroutine; j++ {
+				local = append(local, g.NewID("p"))
+			}
+			mu.Lock()
+			ids = append(ids, local...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return ids
+}
+
+func TestIDGeneratorsUnique(t *testing.T) {
+	generators := map[string]IDGenerator{
+		"timestamp": NewTimestampGenerator(),
+		"uuidv4":    NewUUIDv4Generator(),
+		"uuidv7":    NewUUIDv7Generator(),
+		"ulid":      NewULIDGenerator(),
+		"snowflake": NewSnowflakeGenerator(1),
+	}
+
+	for name, g := range generators {
+		t.Run(name, func(t *testing.T) {
+			ids := generateConcurrently(g, 8, 1000)
+			seen := make(map[string]struct{}, len(ids))
+			for _, id := range ids {
+				if _, ok := seen[id]; ok {
+					t.Fatalf("Duplicate ID %s", id)
+				}
+				seen[id] = struct{}{}
+			}
+		})
+	}
+}
+
+func TestIDGeneratorFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		g       IDGenerator
+		pattern string
+	}{
+		{name: "uuidv4", g: NewUUIDv4Generator(), pattern: `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}_p$`},
+		{name: "uuidv7", g: NewUUIDv7Generator(), pattern: `^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}_p$`},
+		{name: "ulid", g: NewULIDGenerator(), pattern: `^[0-7][0-9A-HJKMNP-TV-Z]{25}_p$`},
+		{name: "snowflake", g: NewSnowflakeGenerator(1), pattern: `^[0-9]+_p$`},
+		{name: "timestamp", g: NewTimestampGenerator(), pattern: `^\d{4}-\d{2}-\d{2}_\d{2}:\d{2}:\d{2}_p$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if id := tt.g.NewID("p"); !regexp.MustCompile(tt.pattern).MatchString(id) {
+				t.Errorf("ID %s does not match %s", id, tt.pattern)
+			}
+		})
+	}
+
+	if id := NewUUIDv4Generator().NewID(""); strings.Contains(id, "_") {
+		t.Errorf("Expected no postfix separator for empty postfix, got %s", id)
+	}
+}
+
+func TestIDGeneratorsOrdered(t *testing.T) {
+	for name, g := range map[string]IDGenerator{"uuidv7": NewUUIDv7Generator(), "ulid": NewULIDGenerator()} {
+		ids := make([]string, 0, 5000)
+		for i := 0; i < cap(ids); i++ {
+			ids = append(ids, g.NewID(""))
+		}
+		if !sort.StringsAreSorted(ids) {
+			t.Errorf("Expected %s IDs to be monotonic", name)
+		}
+	}
+
+	g := NewSnowflakeGenerator(3)
+	prev := int64(0)
+	for i := 0; i < 5000; i++ {
+		id, err := strconv.ParseInt(g.NewID(""), 10, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id <= prev {
+			t.Fatalf("Expected snowflake IDs to increase, got %d after %d", id, prev)
+		}
+		if node := id >> 12 & 0x3ff; node != 3 {
+			t.Fatalf("Expected node 3, got %d", node)
+		}
+		prev = id
+	}
+}
+
+func TestTimestampGeneratorSequence(t *testing.T) {
+	g := NewTimestampGenerator()
+	first := g.NewID("same")
+	second := g.NewID("same")
+
+	if first == second {
+		t.Fatalf("Expected distinct IDs, got %s twice", first)
+	}
+	if _, err := time.Parse(time.DateOnly, strings.Split(second, "_")[0]); err != nil {
+		t.Errorf("Expected date prefix in %s", second)
+	}
+}
+
+func TestGetCtxIDGenerator(t *testing.T) {
+	defer SetIDGenerator(nil)
+
+	ctx, cancel := GetCtx("job", WithIDGenerator(IDGeneratorFunc(func(postfix string) string {
+		return "fixed_" + postfix
+	})))
+	defer cancel()
+	if logID, _ := LogIDFrom(ctx); logID != "fixed_job" {
+		t.Errorf("Expected per-call generator, got %s", logID)
+	}
+
+	SetIDGenerator(UUIDv4IDs)
+	ctx, cancel = GetCtx("job")
+	defer cancel()
+	if logID, _ := LogIDFrom(ctx); !strings.HasSuffix(logID, "_job") || len(logID) != 36+len("_job") {
+		t.Errorf("Expected global UUIDv4 generator, got %s", logID)
+	}
+}
+
+func TestTimestampGeneratorClockBehind(t *testing.T) {
+	g := NewTimestampGenerator()
+	// A caller that already observed a later second, or a clock stepping
+	// back, must not reset the sequence.
+	g.second = time.Now().Unix() + 60
+	g.seq = 3
+
+	seen := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		id := g.NewID("p")
+		if seen[id] {
+			t.Fatalf("Duplicate ID %s", id)
+		}
+		seen[id] = true
+	}
+
+	want := time.Unix(g.second, 0).UTC().Format(time.TimeOnly)
+	for id := range seen {
+		if !strings.Contains(id, want) {
+			t.Errorf("Expected %s to stay at the later second %s", id, want)
+		}
+	}
+}
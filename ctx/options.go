@@ -0,0 +1,141 @@
+package ctx
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+type Option func(*options)
+
+type options struct {
+	idGenerator IDGenerator
+	deadline    time.Time
+	timeout     *time.Duration
+	cause       error
+	announcer   *Announcer
+	signals     []os.Signal
+	gracePeriod time.Duration
+	carriers    []Carrier
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *options) newLogID(postfix string) string {
+	if o.idGenerator != nil {
+		return o.idGenerator.NewID(postfix)
+	}
+	return NewLogID(postfix)
+}
+
+func (o *options) announce(ctx context.Context, logID string) {
+	if o.announcer == nil {
+		announce(ctx, logID)
+		return
+	}
+	if a := *o.announcer; a != nil {
+		a(ctx, logID)
+	}
+}
+
+// resolveDeadline resolves the deadline and timeout options against the current
+// time, so that options can be built once and reused.
+func (o *options) resolveDeadline() time.Time {
+	deadline := o.deadline
+	if o.timeout != nil {
+		if d := time.Now().Add(*o.timeout); deadline.IsZero() || d.Before(deadline) {
+			deadline = d
+		}
+	}
+	return deadline
+}
+
+// derive returns a child of parent honouring the deadline and cause options.
+// The returned cancel cancels with the configured cause.
+func (o *options) derive(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancelCause := context.WithCancelCause(parent)
+	deadline := o.resolveDeadline()
+	if deadline.IsZero() {
+		return ctx, func() { cancelCause(o.cause) }
+	}
+
+	ctx, stop := context.WithDeadlineCause(ctx, deadline, o.cause)
+	return ctx, func() {
+		cancelCause(o.cause)
+		stop()
+	}
+}
+
+// WithIDGenerator overrides the global generator for a single call.
+func WithIDGenerator(g IDGenerator) Option {
+	return func(o *options) {
+		o.idGenerator = g
+	}
+}
+
+// WithTimeout cancels the context d after it is created. Combined with
+// WithDeadline, the earlier of the two applies.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if o.timeout == nil || d < *o.timeout {
+			o.timeout = &d
+		}
+	}
+}
+
+func WithDeadline(deadline time.Time) Option {
+	return func(o *options) {
+		if o.deadline.IsZero() || deadline.Before(o.deadline) {
+			o.deadline = deadline
+		}
+	}
+}
+
+// WithCause sets the error reported by context.Cause when the context is
+// cancelled or its deadline passes.
+func WithCause(cause error) Option {
+	return func(o *options) {
+		o.cause = cause
+	}
+}
+
+// WithAnnouncer overrides the global announcer for a single call. A nil
+// announcer disables the announcement.
+func WithAnnouncer(a Announcer) Option {
+	return func(o *options) {
+		o.announcer = &a
+	}
+}
+
+func WithoutAnnounce() Option {
+	return WithAnnouncer(nil)
+}
+
+// WithSignals sets the signals GetSignalCtx listens for, SIGINT and SIGTERM by
+// default.
+func WithSignals(signals ...os.Signal) Option {
+	return func(o *options) {
+		o.signals = signals
+	}
+}
+
+// WithGracePeriod limits how long GetSignalCtx waits for shutdown hooks before
+// exiting.
+func WithGracePeriod(d time.Duration) Option {
+	return func(o *options) {
+		o.gracePeriod = d
+	}
+}
+
+// WithCarriers limits the values kept by Detach to those copied by carriers.
+func WithCarriers(carriers ...Carrier) Option {
+	return func(o *options) {
+		o.carriers = append([]Carrier{}, carriers...)
+	}
+}
@@ -0,0 +1,162 @@
+package ctx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/marcuspeh/go-tools/logger"
+)
+
+const defaultGracePeriod = 10 * time.Second
+
+// exit is replaced in tests.
+var exit = os.Exit
+
+type shutdownCtxKey struct{}
+
+// SignalError is the cause of a context cancelled by GetSignalCtx.
+type SignalError struct {
+	Signal os.Signal
+}
+
+func (e *SignalError) Error() string {
+	return fmt.Sprintf("received signal %v", e.Signal)
+}
+
+// ShutdownHook is run with a context that stays valid for the grace period.
+type ShutdownHook func(ctx context.Context) error
+
+type shutdown struct {
+	mu    sync.Mutex
+	hooks []ShutdownHook
+
+	grace    time.Duration
+	sigCh    chan os.Signal
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// GetSignalCtx is GetCtx for a program's root context. The context is
+// cancelled with a *SignalError cause on the first SIGINT or SIGTERM (see
+// WithSignals), after which the hooks registered with OnShutdown run in
+// reverse order for at most the grace period (see WithGracePeriod). The
+// logger is then closed and the process exits with 128 plus the signal
+// number. A second signal skips the remaining hooks and exits immediately.
+//
+// GetSignalCtx closes the logger itself, so it stops any handlers started by
+// logger.FlushOnSignal, whose re-raised signal would otherwise count as the
+// second signal. Do not call FlushOnSignal afterwards.
+//
+// The returned cancel stops listening for signals and cancels the context
+// without running the hooks.
+func GetSignalCtx(postfix string, opts ...Option) (context.Context, context.CancelFunc) {
+	o := newOptions(opts...)
+	s := &shutdown{
+		grace: o.gracePeriod,
+		sigCh: make(chan os.Signal, 1),
+		done:  make(chan struct{}),
+	}
+	if s.grace <= 0 {
+		s.grace = defaultGracePeriod
+	}
+	signals := o.signals
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	parent, cancelCause := context.WithCancelCause(context.Background())
+	parent = context.WithValue(parent, shutdownCtxKey{}, s)
+	ctx, cancel := GetCtxFrom(parent, postfix, opts...)
+
+	logger.StopFlushOnSignal()
+	signal.Notify(s.sigCh, signals...)
+	go s.watch(ctx, cancelCause)
+
+	return ctx, func() {
+		s.stop()
+		cancel()
+		cancelCause(nil)
+	}
+}
+
+// OnShutdown registers hook to run when the signal context ctx was derived
+// from receives a signal. It reports false if ctx does not come from
+// GetSignalCtx.
+func OnShutdown(ctx context.Context, hook ShutdownHook) bool {
+	s, ok := ctx.Value(shutdownCtxKey{}).(*shutdown)
+	if !ok || hook == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hooks = append(s.hooks, hook)
+	return true
+}
+
+func (s *shutdown) stop() {
+	s.stopOnce.Do(func() {
+		signal.Stop(s.sigCh)
+		close(s.done)
+	})
+}
+
+func (s *shutdown) watch(ctx context.Context, cancel context.CancelCauseFunc) {
+	var sig os.Signal
+	select {
+	case sig = <-s.sigCh:
+	case <-s.done:
+		return
+	}
+
+	logger.Info(ctx, "shutting down", zap.Stringer("signal", sig), zap.Duration("grace_period", s.grace))
+	cancel(&SignalError{Signal: sig})
+
+	graceCtx, stop := context.WithTimeout(context.WithoutCancel(ctx), s.grace)
+	defer stop()
+
+	finished := make(chan struct{})
+	go func() {
+		s.runHooks(graceCtx)
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-graceCtx.Done():
+		logger.Warn(ctx, "shutdown grace period elapsed")
+	case forced := <-s.sigCh:
+		logger.Warn(ctx, "forced shutdown", zap.Stringer("signal", forced))
+	}
+
+	s.stop()
+	_ = logger.Close()
+	exit(exitCode(sig))
+}
+
+func (s *shutdown) runHooks(ctx context.Context) {
+	s.mu.Lock()
+	hooks := append([]ShutdownHook{}, s.hooks...)
+	s.mu.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i](ctx); err != nil {
+			logger.Error(ctx, "shutdown hook failed", logger.ErrorLog(err))
+		}
+	}
+}
+
+func exitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
@@ -0,0 +1,165 @@
+package ctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/marcuspeh/go-tools/logger"
+)
+
+func stubExit(t *testing.T) <-chan int {
+	t.Helper()
+
+	codes := make(chan int, 1)
+	exit = func(code int) { codes <- code }
+	t.Cleanup(func() { exit = os.Exit })
+	return codes
+}
+
+func interrupt(t *testing.T) {
+	t.Helper()
+
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("Failed to find own process: %v", err)
+	}
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Fatalf("Failed to send interrupt: %v", err)
+	}
+}
+
+func waitExit(t *testing.T, codes <-chan int) int {
+	t.Helper()
+
+	select {
+	case code := <-codes:
+		return code
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected process to exit")
+		return 0
+	}
+}
+
+func TestGetSignalCtx(t *testing.T) {
+	logger.NewObserved(t)
+	codes := stubExit(t)
+
+	ctx, cancel := GetSignalCtx("signal", WithoutAnnounce(), WithGracePeriod(time.Second))
+	defer cancel()
+
+	var order []int
+	for i := 1; i <= 3; i++ {
+		i := i
+		if !OnShutdown(ctx, func(hookCtx context.Context) error {
+			if hookCtx.Err() != nil {
+				t.Errorf("Expected hook context to be live, got %v", hookCtx.Err())
+			}
+			order = append(order, i)
+			return nil
+		}) {
+			t.Fatal("Expected OnShutdown to register hook")
+		}
+	}
+
+	interrupt(t)
+
+	if code := waitExit(t, codes); code != 128+int(syscall.SIGINT) {
+		t.Errorf("Expected exit code %d, got %d", 128+int(syscall.SIGINT), code)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("Expected context to be cancelled")
+	}
+
+	var sigErr *SignalError
+	if !errors.As(context.Cause(ctx), &sigErr) || sigErr.Signal != os.Interrupt {
+		t.Errorf("Expected SignalError cause, got %v", context.Cause(ctx))
+	}
+	if len(order) != 3 || order[0] != 3 || order[1] != 2 || order[2] != 1 {
+		t.Errorf("Expected hooks in reverse order, got %v", order)
+	}
+}
+
+func TestGetSignalCtxGracePeriod(t *testing.T) {
+	logger.NewObserved(t)
+	codes := stubExit(t)
+
+	ctx, cancel := GetSignalCtx("grace", WithoutAnnounce(), WithGracePeriod(50*time.Millisecond))
+	defer cancel()
+
+	hookErr := make(chan error, 1)
+	OnShutdown(ctx, func(hookCtx context.Context) error {
+		<-hookCtx.Done()
+		hookErr <- hookCtx.Err()
+		return nil
+	})
+
+	interrupt(t)
+	waitExit(t, codes)
+
+	if err := <-hookErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected hook context to expire, got %v", err)
+	}
+}
+
+func TestGetSignalCtxForceExit(t *testing.T) {
+	logger.NewObserved(t)
+	codes := stubExit(t)
+
+	ctx, cancel := GetSignalCtx("force", WithoutAnnounce(), WithGracePeriod(time.Minute))
+	defer cancel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	OnShutdown(ctx, func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	interrupt(t)
+	<-started
+	interrupt(t)
+
+	waitExit(t, codes)
+}
+
+func TestGetSignalCtxCancel(t *testing.T) {
+	ctx, cancel := GetSignalCtx("cancel", WithoutAnnounce())
+	cancel()
+
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("Expected context to be cancelled, got %v", ctx.Err())
+	}
+	if OnShutdown(context.Background(), func(context.Context) error { return nil }) {
+		t.Error("Expected OnShutdown to fail without a signal context")
+	}
+}
+
+func TestGetSignalCtxTakesOverFlushOnSignal(t *testing.T) {
+	logger.NewObserved(t)
+	codes := stubExit(t)
+
+	stopFlush := logger.FlushOnSignal()
+	defer stopFlush()
+
+	ctx, cancel := GetSignalCtx("takeover", WithoutAnnounce(), WithGracePeriod(time.Second))
+	defer cancel()
+
+	ran := false
+	OnShutdown(ctx, func(context.Context) error {
+		ran = true
+		return nil
+	})
+
+	interrupt(t)
+	waitExit(t, codes)
+
+	if !ran {
+		t.Error("Expected shutdown hook to run")
+	}
+}
@@ -0,0 +1,239 @@
+package ctx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/marcuspeh/go-tools/logger"
+)
+
+const (
+	TraceparentHeader = "traceparent"
+	TracestateHeader  = "tracestate"
+
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+
+	// FlagSampled is the sampled bit of the trace-flags field.
+	FlagSampled byte = 0x01
+
+	traceparentLen     = 55
+	maxTracestateItems = 32
+)
+
+var ErrInvalidTraceparent = errors.New("invalid traceparent")
+
+type spanCtxKey struct{}
+
+func init() {
+	logger.RegisterIdentifierField(TraceIDKey, SpanIDKey)
+	logger.RegisterExtractor(func(ctx context.Context) []zap.Field {
+		sc, ok := SpanContextFrom(ctx)
+		if !ok {
+			return nil
+		}
+		return []zap.Field{
+			zap.String(TraceIDKey, sc.TraceID.String()),
+			zap.String(SpanIDKey, sc.SpanID.String()),
+		}
+	})
+}
+
+type TraceID [16]byte
+
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+type SpanID [8]byte
+
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanContext is the W3C Trace Context of the current span. State holds the
+// vendor specific tracestate list, which is passed through unchanged.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Flags   byte
+	State   string
+}
+
+// NewSpanContext starts a new sampled trace.
+func NewSpanContext() SpanContext {
+	var sc SpanContext
+	for !sc.TraceID.IsValid() {
+		_, _ = rand.Read(sc.TraceID[:])
+	}
+	sc.SpanID = newSpanID()
+	sc.Flags = FlagSampled
+	return sc
+}
+
+// Child returns a span of the same trace with a new span ID.
+func (sc SpanContext) Child() SpanContext {
+	sc.SpanID = newSpanID()
+	return sc
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+func (sc SpanContext) Sampled() bool {
+	return sc.Flags&FlagSampled != 0
+}
+
+// Traceparent formats sc as a version 00 traceparent header value.
+func (sc SpanContext) Traceparent() string {
+	var b strings.Builder
+	b.Grow(traceparentLen)
+	b.WriteString("00-")
+	b.WriteString(sc.TraceID.String())
+	b.WriteByte('-')
+	b.WriteString(sc.SpanID.String())
+	b.WriteByte('-')
+	b.WriteString(hex.EncodeToString([]byte{sc.Flags}))
+	return b.String()
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+// ParseTraceparent parses a traceparent header value. Versions above 00 are
+// accepted as long as they start with the version 00 fields.
+func ParseTraceparent(s string) (SpanContext, error) {
+	var sc SpanContext
+
+	s = strings.TrimSpace(s)
+	if len(s) < traceparentLen || (len(s) > traceparentLen && s[traceparentLen] != '-') {
+		return sc, ErrInvalidTraceparent
+	}
+	if s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return sc, ErrInvalidTraceparent
+	}
+
+	version, ok := decodeLowerHex(s[0:2])
+	if !ok || version[0] == 0xff || (version[0] == 0 && len(s) != traceparentLen) {
+		return sc, ErrInvalidTraceparent
+	}
+	traceID, ok := decodeLowerHex(s[3:35])
+	if !ok {
+		return sc, ErrInvalidTraceparent
+	}
+	spanID, ok := decodeLowerHex(s[36:52])
+	if !ok {
+		return sc, ErrInvalidTraceparent
+	}
+	flags, ok := decodeLowerHex(s[53:55])
+	if !ok {
+		return sc, ErrInvalidTraceparent
+	}
+
+	copy(sc.TraceID[:], traceID)
+	copy(sc.SpanID[:], spanID)
+	sc.Flags = flags[0]
+	if !sc.IsValid() {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	return sc, nil
+}
+
+func decodeLowerHex(s string) ([]byte, bool) {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < '0' || s[i] > '9') && (s[i] < 'a' || s[i] > 'f') {
+			return nil, false
+		}
+	}
+	b, err := hex.DecodeString(s)
+	return b, err == nil
+}
+
+// ParseTracestate normalises a tracestate header value, dropping malformed
+// and duplicate list members and keeping at most 32 of them.
+func ParseTracestate(s string) string {
+	seen := map[string]bool{}
+	members := make([]string, 0, strings.Count(s, ",")+1)
+	for _, member := range strings.Split(s, ",") {
+		member = strings.TrimSpace(member)
+		key, val, ok := strings.Cut(member, "=")
+		if !ok || !validTracestateKey(key) || !validTracestateValue(val) || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		members = append(members, member)
+		if len(members) == maxTracestateItems {
+			break
+		}
+	}
+	return strings.Join(members, ",")
+}
+
+func validTracestateKey(key string) bool {
+	if key == "" || len(key) > 256 {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case i > 0 && (c == '_' || c == '-' || c == '*' || c == '/' || c == '@'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func validTracestateValue(val string) bool {
+	if val == "" || len(val) > 256 || val[len(val)-1] == ' ' {
+		return false
+	}
+	for i := 0; i < len(val); i++ {
+		if val[i] < ' ' || val[i] > '~' || val[i] == ',' || val[i] == '=' {
+			return false
+		}
+	}
+	return true
+}
+
+func WithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanCtxKey{}, sc)
+}
+
+func SpanContextFrom(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanCtxKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// StartSpan returns a context holding a child of the span in ctx, or the root
+// of a new trace when ctx has none.
+func StartSpan(ctx context.Context) (context.Context, SpanContext) {
+	sc, ok := SpanContextFrom(ctx)
+	if ok {
+		sc = sc.Child()
+	} else {
+		sc = NewSpanContext()
+	}
+	return WithSpanContext(ctx, sc), sc
+}
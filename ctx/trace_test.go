@@ -0,0 +1,136 @@
+package ctx
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/marcuspeh/go-tools/logger"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParseTraceparent(t *testing.T) {
+	sc, err := ParseTraceparent(testTraceparent)
+	if err != nil {
+		t.Fatalf("ParseTraceparent failed: %v", err)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" {
+		t.Errorf("Unexpected IDs: %s %s", sc.TraceID, sc.SpanID)
+	}
+	if !sc.Sampled() {
+		t.Error("Expected sampled flag")
+	}
+	if sc.Traceparent() != testTraceparent {
+		t.Errorf("Expected round trip, got %s", sc.Traceparent())
+	}
+
+	if _, err := ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra"); err != nil {
+		t.Errorf("Expected future version to parse, got %v", err)
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473g-00f067aa0ba902b7-01",
+	}
+	for _, s := range invalid {
+		if _, err := ParseTraceparent(s); err != ErrInvalidTraceparent {
+			t.Errorf("Expected %q to be invalid, got %v", s, err)
+		}
+	}
+}
+
+func TestParseTracestate(t *testing.T) {
+	got := ParseTracestate("rojo=00f067aa0ba902b7, congo=t61rcWkgMzE,,bad key=1,rojo=dup,Upper=x,vendor@tenant=v")
+	want := "rojo=00f067aa0ba902b7,congo=t61rcWkgMzE,vendor@tenant=v"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestStartSpan(t *testing.T) {
+	ctx, root := StartSpan(context.Background())
+	if !root.IsValid() || !root.Sampled() {
+		t.Fatalf("Expected valid sampled root, got %+v", root)
+	}
+
+	ctx, child := StartSpan(ctx)
+	if child.TraceID != root.TraceID || child.SpanID == root.SpanID {
+		t.Errorf("Expected child of %+v, got %+v", root, child)
+	}
+	if sc, _ := SpanContextFrom(ctx); sc != child {
+		t.Errorf("Expected child in context, got %+v", sc)
+	}
+}
+
+func TestTraceFieldsLogged(t *testing.T) {
+	observed := logger.NewObserved(t)
+
+	sc, _ := ParseTraceparent(testTraceparent)
+	logger.Info(WithSpanContext(context.Background(), sc), "traced")
+
+	entries := observed.All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	fields := entries[0].Fields
+	if fields[TraceIDKey] != sc.TraceID.String() || fields[SpanIDKey] != sc.SpanID.String() {
+		t.Errorf("Expected trace fields, got %v", fields)
+	}
+}
+
+func TestMiddlewareTraceContext(t *testing.T) {
+	var got SpanContext
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = SpanContextFrom(r.Context())
+
+		out := http.Header{}
+		InjectHeaders(r.Context(), out)
+		if out.Get(TraceparentHeader) != got.Traceparent() || out.Get(TracestateHeader) != got.State {
+			t.Errorf("Expected trace headers to be injected, got %v", out)
+		}
+	}), WithoutAnnounce())
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(TraceparentHeader, testTraceparent)
+	req.Header.Set(TracestateHeader, "congo=t61rcWkgMzE")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	parent, _ := ParseTraceparent(testTraceparent)
+	if got.TraceID != parent.TraceID || got.SpanID == parent.SpanID || got.State != "congo=t61rcWkgMzE" {
+		t.Errorf("Expected child span of %+v, got %+v", parent, got)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if !got.IsValid() || got.TraceID == parent.TraceID {
+		t.Errorf("Expected new trace, got %+v", got)
+	}
+}
+
+func TestTraceFieldsNotRedacted(t *testing.T) {
+	var buf bytes.Buffer
+	l := logger.NewLogger(logger.WithoutFile(), logger.WithSink(logger.Sink{Writer: &buf}),
+		logger.WithRedaction(logger.NewRedactor(logger.DefaultRedactionConfig())))
+
+	// The hex IDs below contain Luhn-valid digit runs.
+	sc, err := ParseTraceparent("00-00000000000000004111111111111111-4111111111111111-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Info(WithSpanContext(context.Background(), sc), "traced")
+
+	for _, kept := range []string{sc.TraceID.String(), sc.SpanID.String()} {
+		if !strings.Contains(buf.String(), kept) {
+			t.Errorf("Expected %s to be kept, got %s", kept, buf.String())
+		}
+	}
+}
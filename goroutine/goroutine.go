@@ -3,6 +3,7 @@ package goroutine
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
 
 	"golang.org/x/sync/errgroup"
 )
@@ -16,6 +17,28 @@ type ErrGroupImpl struct {
 	grp errgroup.Group
 }
 
+type PanicError struct {
+	Value any
+	stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic occured %v", e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+func (e *PanicError) Stack() []byte {
+	return e.stack
+}
+
+func (e *PanicError) Kind() string {
+	return "panic"
+}
+
 func NewErrGroup() ErrGroup {
 	return &ErrGroupImpl{
 		grp: errgroup.Group{},
@@ -26,7 +49,7 @@ func (m *ErrGroupImpl) Run(ctx context.Context, fn func() error) {
 	m.grp.Go(func() (err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				err = fmt.Errorf("panic occured %v", r)
+				err = &PanicError{Value: r, stack: debug.Stack()}
 			}
 		}()
 
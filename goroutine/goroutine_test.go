@@ -56,3 +56,27 @@ func TestErrGroup_Panic(t *testing.T) {
 		t.Errorf("Expected panic error message, got %v", err)
 	}
 }
+
+func TestErrGroup_PanicError(t *testing.T) {
+	g := NewErrGroup()
+	cause := errors.New("boom")
+
+	g.Run(context.Background(), func() error {
+		panic(cause)
+	})
+
+	err := g.Wait()
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected *PanicError, got %T", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("Expected panic value to be unwrapped")
+	}
+	if len(panicErr.Stack()) == 0 {
+		t.Error("Expected stack trace to be captured")
+	}
+	if panicErr.Kind() != "panic" {
+		t.Errorf("Expected kind panic, got %s", panicErr.Kind())
+	}
+}
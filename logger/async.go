@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const defaultAsyncQueueSize = 1024
+
+var errAsyncClosed = errors.New("logger: async writer closed")
+
+type AsyncPolicy int
+
+const (
+	// AsyncDrop discards entries when the queue is full.
+	AsyncDrop AsyncPolicy = iota
+	// AsyncBlock makes the caller wait for space in the queue.
+	AsyncBlock
+)
+
+type Async struct {
+	QueueSize int
+	Policy    AsyncPolicy
+}
+
+type AsyncStats struct {
+	Depth    int
+	Capacity int
+	Dropped  uint64
+	Errors   uint64
+}
+
+// WithAsync moves writes to every sink onto a background goroutine with a
+// bounded queue. Sync waits for queued entries and Close drains the queue.
+func WithAsync(async Async) Option {
+	return func(c *config) {
+		c.async = &async
+	}
+}
+
+// AsyncStats sums the queue metrics of every async writer of l.
+func (l *Logger) AsyncStats() AsyncStats {
+	var stats AsyncStats
+	for _, w := range l.asyncs {
+		stats.Depth += len(w.queue)
+		stats.Capacity += cap(w.queue)
+		stats.Dropped += w.dropped.Load()
+		stats.Errors += w.errors.Load()
+	}
+	return stats
+}
+
+func GetAsyncStats() AsyncStats {
+	return Default().AsyncStats()
+}
+
+type asyncItem struct {
+	data    []byte
+	flushed chan struct{}
+}
+
+type asyncWriteSyncer struct {
+	ws     zapcore.WriteSyncer
+	policy AsyncPolicy
+	queue  chan asyncItem
+	done   chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+
+	dropped atomic.Uint64
+	errors  atomic.Uint64
+}
+
+func newAsyncWriteSyncer(ws zapcore.WriteSyncer, async Async) *asyncWriteSyncer {
+	size := async.QueueSize
+	if size <= 0 {
+		size = defaultAsyncQueueSize
+	}
+
+	w := &asyncWriteSyncer{
+		ws:     ws,
+		policy: async.Policy,
+		queue:  make(chan asyncItem, size),
+		done:   make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *asyncWriteSyncer) run() {
+	defer close(w.done)
+
+	for item := range w.queue {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+		if _, err := w.ws.Write(item.data); err != nil {
+			w.errors.Add(1)
+		}
+	}
+}
+
+func (w *asyncWriteSyncer) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return 0, errAsyncClosed
+	}
+
+	// zap reuses the encoder buffer once Write returns.
+	item := asyncItem{data: append([]byte(nil), p...)}
+	if w.policy == AsyncBlock {
+		w.queue <- item
+		return len(p), nil
+	}
+
+	select {
+	case w.queue <- item:
+	default:
+		w.dropped.Add(1)
+	}
+	return len(p), nil
+}
+
+// Sync waits until every entry queued before the call has been written.
+func (w *asyncWriteSyncer) Sync() error {
+	w.mu.RLock()
+	if w.closed {
+		w.mu.RUnlock()
+		return nil
+	}
+
+	flushed := make(chan struct{})
+	w.queue <- asyncItem{flushed: flushed}
+	w.mu.RUnlock()
+
+	<-flushed
+	return w.ws.Sync()
+}
+
+func (w *asyncWriteSyncer) Stop() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+
+	<-w.done
+	return w.ws.Sync()
+}
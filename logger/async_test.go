@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type blockingWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	once    sync.Once
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.entered) })
+	<-w.release
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.buf.Write(p)
+}
+
+func (w *blockingWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.buf.String()
+}
+
+func TestWithAsyncSync(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "async.log")
+	l := NewLogger(WithFile(logPath), WithAsync(Async{QueueSize: 16, Policy: AsyncBlock}))
+	defer l.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		l.Info(ctx, "async entry")
+	}
+	if err := l.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(content), "async entry"); got != 100 {
+		t.Errorf("Expected 100 entries after Sync, got %d", got)
+	}
+	if stats := l.AsyncStats(); stats.Dropped != 0 || stats.Capacity != 16 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestWithAsyncDrop(t *testing.T) {
+	w := &blockingWriter{entered: make(chan struct{}), release: make(chan struct{})}
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: w}), WithAsync(Async{QueueSize: 2, Policy: AsyncDrop}))
+
+	ctx := context.Background()
+	l.Info(ctx, "maybe dropped")
+	<-w.entered
+	for i := 0; i < 9; i++ {
+		l.Info(ctx, "maybe dropped")
+	}
+
+	// One entry is held by the blocked writer and two fill the queue.
+	stats := l.AsyncStats()
+	if stats.Depth != 2 || stats.Dropped != 7 {
+		t.Errorf("Unexpected stats while blocked %+v", stats)
+	}
+
+	close(w.release)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if written := strings.Count(w.String(), "maybe dropped"); written != 3 {
+		t.Errorf("Expected 3 entries written, got %d", written)
+	}
+	if l.AsyncStats().Depth != 0 {
+		t.Error("Expected Close to drain the queue")
+	}
+}
@@ -0,0 +1,305 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+
+	// Every audit line starts with its hash so it can be verified against the
+	// exact bytes that were hashed.
+	auditHashPrefix = `{"hash":"`
+	auditHashLen    = sha256.Size * 2
+)
+
+var (
+	ErrAuditNotInitialised = errors.New("logger: audit log not initialised")
+	ErrAuditTampered       = errors.New("logger: audit log hash chain broken")
+
+	auditor atomic.Pointer[Auditor]
+)
+
+type AuditEvent struct {
+	Actor   string
+	Action  string
+	Target  string
+	Outcome string
+}
+
+type auditRecord struct {
+	Timestamp string                 `json:"timestamp"`
+	LogID     string                 `json:"log_id"`
+	Actor     string                 `json:"actor"`
+	Action    string                 `json:"action"`
+	Target    string                 `json:"target"`
+	Outcome   string                 `json:"outcome"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+	PrevHash  string                 `json:"prev_hash"`
+}
+
+// Auditor appends audit events to a file that is never rotated or sampled.
+// Each entry carries the hash of the previous one so that VerifyAudit can
+// detect edited, reordered or removed entries. Removing entries from the end
+// leaves a valid chain; record Head outside the file and check it with
+// VerifyAuditHead to detect that too.
+type Auditor struct {
+	mu       sync.Mutex
+	file     *os.File
+	prevHash string
+}
+
+func NewAuditor(path string) (*Auditor, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	prevHash, err := lastAuditHash(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Auditor{file: file, prevHash: prevHash}, nil
+}
+
+func InitAudit(path string) error {
+	a, err := NewAuditor(path)
+	if err != nil {
+		return err
+	}
+
+	if previous := auditor.Swap(a); previous != nil {
+		return previous.Close()
+	}
+	return nil
+}
+
+func CloseAudit() error {
+	if a := auditor.Swap(nil); a != nil {
+		return a.Close()
+	}
+	return nil
+}
+
+func Audit(ctx context.Context, event AuditEvent, fields ...zap.Field) error {
+	a := auditor.Load()
+	if a == nil {
+		return ErrAuditNotInitialised
+	}
+	return a.Audit(ctx, event, fields...)
+}
+
+func (a *Auditor) Audit(ctx context.Context, event AuditEvent, fields ...zap.Field) error {
+	logID, ok := LogIDFromContext(ctx)
+	if !ok {
+		logID = "unknown"
+	}
+
+	record := auditRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		LogID:     logID,
+		Actor:     event.Actor,
+		Action:    event.Action,
+		Target:    event.Target,
+		Outcome:   event.Outcome,
+	}
+	if len(fields) > 0 {
+		enc := zapcore.NewMapObjectEncoder()
+		for _, field := range fields {
+			field.AddTo(enc)
+		}
+		record.Fields = enc.Fields
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.file == nil {
+		return os.ErrClosed
+	}
+
+	record.PrevHash = a.prevHash
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	hash := auditHash(a.prevHash, body)
+	line := make([]byte, 0, len(auditHashPrefix)+auditHashLen+len(body)+2)
+	line = append(line, auditHashPrefix...)
+	line = append(line, hash...)
+	line = append(line, `",`...)
+	line = append(line, body[1:]...)
+	line = append(line, '\n')
+
+	if _, err := a.file.Write(line); err != nil {
+		return err
+	}
+	if err := a.file.Sync(); err != nil {
+		return err
+	}
+
+	a.prevHash = hash
+	return nil
+}
+
+// Head returns the hash of the last entry written, or "" for an empty file.
+func (a *Auditor) Head() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.prevHash
+}
+
+// AuditHead returns the Head of the default auditor.
+func AuditHead() string {
+	if a := auditor.Load(); a != nil {
+		return a.Head()
+	}
+	return ""
+}
+
+func (a *Auditor) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+// VerifyAudit checks the hash chain of the audit file at path. It cannot tell
+// whether entries were removed from the end; use VerifyAuditHead for that.
+func VerifyAudit(path string) error {
+	_, err := verifyAuditChain(path)
+	return err
+}
+
+// VerifyAuditHead checks the hash chain of the audit file at path and that it
+// ends with head, as returned by Auditor.Head.
+func VerifyAuditHead(path, head string) error {
+	last, err := verifyAuditChain(path)
+	if err != nil {
+		return err
+	}
+	if last != head {
+		return fmt.Errorf("%w: head mismatch, trailing entries may have been removed", ErrAuditTampered)
+	}
+	return nil
+}
+
+func verifyAuditChain(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	prevHash := ""
+	lineNo := 0
+	scanner := newAuditScanner(file)
+	for scanner.Scan() {
+		lineNo++
+
+		hash, body, err := splitAuditLine(scanner.Bytes())
+		if err != nil {
+			return "", fmt.Errorf("%w: line %d: %v", ErrAuditTampered, lineNo, err)
+		}
+
+		var record auditRecord
+		if err := json.Unmarshal(body, &record); err != nil {
+			return "", fmt.Errorf("%w: line %d: %v", ErrAuditTampered, lineNo, err)
+		}
+		if record.PrevHash != prevHash {
+			return "", fmt.Errorf("%w: line %d: previous hash mismatch", ErrAuditTampered, lineNo)
+		}
+		if auditHash(prevHash, body) != hash {
+			return "", fmt.Errorf("%w: line %d: hash mismatch", ErrAuditTampered, lineNo)
+		}
+		prevHash = hash
+	}
+	return prevHash, scanner.Err()
+}
+
+func auditHash(prevHash string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// splitAuditLine returns the hash of a line and the JSON body it was
+// computed over.
+func splitAuditLine(line []byte) (string, []byte, error) {
+	headerLen := len(auditHashPrefix) + auditHashLen + 2
+	if len(line) < headerLen || !bytes.HasPrefix(line, []byte(auditHashPrefix)) {
+		return "", nil, errors.New("missing hash")
+	}
+
+	hash := string(line[len(auditHashPrefix) : len(auditHashPrefix)+auditHashLen])
+	if string(line[headerLen-2:headerLen]) != `",` {
+		return "", nil, errors.New("malformed hash")
+	}
+
+	body := append([]byte{'{'}, line[headerLen:]...)
+	return hash, body, nil
+}
+
+func lastAuditHash(path string) (string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var last []byte
+	scanner := newAuditScanner(file)
+	for scanner.Scan() {
+		last = append(last[:0], scanner.Bytes()...)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if len(last) == 0 {
+		return "", nil
+	}
+
+	hash, _, err := splitAuditLine(last)
+	if err != nil {
+		return "", fmt.Errorf("%w: last line: %v", ErrAuditTampered, err)
+	}
+	return hash, nil
+}
+
+func newAuditScanner(file *os.File) *bufio.Scanner {
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return scanner
+}
@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func writeAuditEvents(t *testing.T, path string, actions ...string) string {
+	t.Helper()
+
+	a, err := NewAuditor(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+
+	ctx := ContextWithLogID(context.Background(), "audit-id")
+	for _, action := range actions {
+		event := AuditEvent{Actor: "alice", Action: action, Target: "user:42", Outcome: AuditOutcomeSuccess}
+		if err := a.Audit(ctx, event, zap.String("ip", "10.0.0.1")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return a.Head()
+}
+
+func TestAuditor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+	writeAuditEvents(t, path, "login", "grant_role")
+	writeAuditEvents(t, path, "logout")
+
+	if err := VerifyAudit(path); err != nil {
+		t.Fatalf("Expected valid chain, got %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(lines))
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[2]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	for key, expected := range map[string]interface{}{
+		"log_id": "audit-id", "actor": "alice", "action": "logout", "target": "user:42", "outcome": "success",
+	} {
+		if entry[key] != expected {
+			t.Errorf("Expected %s=%v, got %v", key, expected, entry[key])
+		}
+	}
+	if fields, _ := entry["fields"].(map[string]interface{}); fields["ip"] != "10.0.0.1" {
+		t.Errorf("Expected extra fields, got %v", entry["fields"])
+	}
+}
+
+func TestVerifyAuditTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(lines [][]byte) [][]byte
+	}{
+		{
+			name: "edited entry",
+			tamper: func(lines [][]byte) [][]byte {
+				lines[1] = bytes.Replace(lines[1], []byte(`"success"`), []byte(`"failure"`), 1)
+				return lines
+			},
+		},
+		{
+			name: "removed entry",
+			tamper: func(lines [][]byte) [][]byte {
+				return append(lines[:1], lines[2:]...)
+			},
+		},
+		{
+			name: "reordered entries",
+			tamper: func(lines [][]byte) [][]byte {
+				lines[0], lines[1] = lines[1], lines[0]
+				return lines
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.log")
+			writeAuditEvents(t, path, "a", "b", "c")
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := tt.tamper(bytes.Split(bytes.TrimSpace(content), []byte("\n")))
+			if err := os.WriteFile(path, append(bytes.Join(lines, []byte("\n")), '\n'), 0640); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := VerifyAudit(path); !errors.Is(err, ErrAuditTampered) {
+				t.Errorf("Expected ErrAuditTampered, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAuditDefault(t *testing.T) {
+	defer CloseAudit()
+
+	if err := Audit(context.Background(), AuditEvent{Action: "noop"}); !errors.Is(err, ErrAuditNotInitialised) {
+		t.Errorf("Expected ErrAuditNotInitialised, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	if err := InitAudit(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := Audit(context.Background(), AuditEvent{Actor: "svc", Action: "rotate_key", Outcome: AuditOutcomeFailure}); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyAuditHead(path, AuditHead()); err != nil {
+		t.Errorf("Expected valid chain, got %v", err)
+	}
+}
+
+func TestVerifyAuditHead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	head := writeAuditEvents(t, path, "a", "b", "c")
+
+	if err := VerifyAuditHead(path, head); err != nil {
+		t.Fatalf("Expected valid chain, got %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	if err := os.WriteFile(path, bytes.Join(lines[:2], nil), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyAudit(path); err != nil {
+		t.Errorf("Expected truncated chain to still be consistent, got %v", err)
+	}
+	if err := VerifyAuditHead(path, head); !errors.Is(err, ErrAuditTampered) {
+		t.Errorf("Expected ErrAuditTampered for removed trailing entry, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+type loggerCtxKey struct{}
+
+type fieldsCtxKey struct{}
+
+type logIDCtxKey struct{}
+
+type Extractor func(ctx context.Context) []zap.Field
+
+var (
+	extractors   []Extractor
+	extractorsMu sync.RWMutex
+)
+
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerCtxKey{}).(*Logger); ok && l != nil {
+		return l
+	}
+	return Default()
+}
+
+func ContextWithLogID(ctx context.Context, logID string) context.Context {
+	return context.WithValue(ctx, logIDCtxKey{}, logID)
+}
+
+func LogIDFromContext(ctx context.Context) (string, bool) {
+	if logID, ok := ctx.Value(logIDCtxKey{}).(string); ok {
+		return logID, true
+	}
+
+	// Contexts built before the typed key used the LogIDKey string directly.
+	switch logID := ctx.Value(LogIDKey).(type) {
+	case nil:
+		return "", false
+	case string:
+		return logID, true
+	default:
+		return fmt.Sprint(logID), true
+	}
+}
+
+func getLogIDField(ctx context.Context) zap.Field {
+	logID, ok := LogIDFromContext(ctx)
+	if !ok {
+		return zap.String(LogIDKey, "unknown")
+	}
+	return zap.String(LogIDKey, logID)
+}
+
+// WithFields returns a context whose downstream log calls include fields in
+// addition to any fields already attached to ctx.
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	existing := FieldsFrom(ctx)
+	merged := make([]zap.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsCtxKey{}, merged)
+}
+
+func FieldsFrom(ctx context.Context) []zap.Field {
+	fields, _ := ctx.Value(fieldsCtxKey{}).([]zap.Field)
+	return fields
+}
+
+// RegisterExtractor adds fn to the extractors run on every log call. It is
+// meant to be called during program initialisation.
+func RegisterExtractor(fn Extractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+
+	extractors = append(extractors, fn)
+}
+
+// RegisterContextKey logs the value stored under key as fieldName whenever it
+// is present in the context.
+func RegisterContextKey(key any, fieldName string) {
+	RegisterExtractor(func(ctx context.Context) []zap.Field {
+		val := ctx.Value(key)
+		if val == nil {
+			return nil
+		}
+		return []zap.Field{zap.Any(fieldName, val)}
+	})
+}
+
+func contextFields(ctx context.Context) []zap.Field {
+	fields := []zap.Field{getLogIDField(ctx)}
+	fields = append(fields, FieldsFrom(ctx)...)
+
+	extractorsMu.RLock()
+	defer extractorsMu.RUnlock()
+
+	for _, extract := range extractors {
+		fields = append(fields, extract(ctx)...)
+	}
+	return fields
+}
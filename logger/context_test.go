@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestFromContext(t *testing.T) {
+	if l := FromContext(context.Background()); l != Default() {
+		t.Error("Expected default logger when context has none")
+	}
+
+	logPath := filepath.Join(t.TempDir(), "ctx.log")
+	l := NewLogger(WithFile(logPath))
+	ctx := NewContext(context.Background(), l)
+
+	if got := FromContext(ctx); got != l {
+		t.Error("Expected logger stored in context")
+	}
+
+	Info(ctx, "routed through context")
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "routed through context") {
+		t.Errorf("Expected package-level Info to use context logger, got %s", content)
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: &buf}))
+
+	ctx := WithFields(context.Background(), zap.String("tenant", "acme"))
+	child := WithFields(ctx, zap.Int("user_id", 42))
+	l.Info(child, "with fields")
+
+	if len(FieldsFrom(ctx)) != 1 {
+		t.Errorf("Expected parent context fields to be unchanged, got %v", FieldsFrom(ctx))
+	}
+	if !strings.Contains(buf.String(), `"tenant":"acme"`) || !strings.Contains(buf.String(), `"user_id":42`) {
+		t.Errorf("Expected context fields in entry, got %s", buf.String())
+	}
+}
+
+type requestPathKey struct{}
+
+func TestRegisterContextKey(t *testing.T) {
+	extractorsMu.RLock()
+	saved := extractors
+	extractorsMu.RUnlock()
+	defer func() {
+		extractorsMu.Lock()
+		extractors = saved
+		extractorsMu.Unlock()
+	}()
+
+	RegisterContextKey(requestPathKey{}, "path")
+	RegisterExtractor(func(ctx context.Context) []zap.Field {
+		return []zap.Field{zap.String("trace_id", "abc")}
+	})
+
+	var buf bytes.Buffer
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: &buf}))
+
+	l.Info(context.Background(), "without path")
+	l.Info(context.WithValue(context.Background(), requestPathKey{}, "/orders"), "with path")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	if strings.Contains(lines[0], `"path"`) {
+		t.Errorf("Expected no path field when key is absent, got %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"path":"/orders"`) {
+		t.Errorf("Expected path field, got %s", lines[1])
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"trace_id":"abc"`) {
+			t.Errorf("Expected extractor field, got %s", line)
+		}
+	}
+}
+
+func TestGetLogIDField(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{name: "missing", ctx: context.Background(), expected: "unknown"},
+		{name: "typed key", ctx: ContextWithLogID(context.Background(), "typed-id"), expected: "typed-id"},
+		{name: "legacy string key", ctx: context.WithValue(context.Background(), LogIDKey, "legacy-id"), expected: "legacy-id"},
+		{name: "legacy non-string value", ctx: context.WithValue(context.Background(), LogIDKey, 42), expected: "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := getLogIDField(tt.ctx)
+			if field.Key != LogIDKey || field.String != tt.expected {
+				t.Errorf("Expected %s=%s, got %s=%s", LogIDKey, tt.expected, field.Key, field.String)
+			}
+		})
+	}
+}
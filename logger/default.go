@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"os"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/marcuspeh/go-tools/env"
+)
+
+const (
+	EnvLogLevel    = "LOG_LEVEL"
+	EnvLogEncoding = "LOG_ENCODING"
+	EnvLogOutput   = "LOG_OUTPUT"
+	// EnvLogLevels sets named logger levels, e.g. "http=warn,cache=debug".
+	EnvLogLevels = "LOG_LEVELS"
+)
+
+// newEnvLogger builds the logger used when Init has not been called. It logs
+// Info and above to stderr unless overridden by LOG_LEVEL, LOG_ENCODING
+// (json|console) and LOG_OUTPUT (stderr|stdout).
+func newEnvLogger() *Logger {
+	level, err := zapcore.ParseLevel(env.GetEnvString(EnvLogLevel))
+	if err != nil {
+		level = zapcore.InfoLevel
+	}
+
+	encoding := EncodingConsole
+	if strings.EqualFold(env.GetEnvString(EnvLogEncoding), string(EncodingJSON)) {
+		encoding = EncodingJSON
+	}
+
+	sink := StderrSink(encoding, nil)
+	if strings.EqualFold(env.GetEnvString(EnvLogOutput), "stdout") {
+		sink.Writer = os.Stdout
+	}
+
+	return NewLogger(WithoutFile(), WithLevel(level), WithSink(sink))
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewEnvLogger(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    string
+		expected zapcore.Level
+	}{
+		{name: "unset", level: "", expected: zapcore.InfoLevel},
+		{name: "debug", level: "debug", expected: zapcore.DebugLevel},
+		{name: "warn", level: "WARN", expected: zapcore.WarnLevel},
+		{name: "invalid", level: "loud", expected: zapcore.InfoLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvLogLevel, tt.level)
+			t.Setenv(EnvLogEncoding, "json")
+			t.Setenv(EnvLogOutput, "stdout")
+
+			l := newEnvLogger()
+			if l.GetLevel() != tt.expected {
+				t.Errorf("Expected level %v, got %v", tt.expected, l.GetLevel())
+			}
+		})
+	}
+}
+
+func TestDefaultConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	results := make([]*Logger, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = Default()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, l := range results {
+		if l == nil || l != results[0] {
+			t.Fatal("Expected every goroutine to observe the same default logger")
+		}
+	}
+}
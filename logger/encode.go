@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	TruncatedValue = "[TRUNCATED]"
+	truncatedKey   = "_truncated"
+)
+
+var emplaceLimits atomic.Pointer[EmplaceLimits]
+
+func init() {
+	emplaceLimits.Store(&EmplaceLimits{MaxDepth: 10, MaxBytes: 64 * 1024})
+}
+
+type EmplaceLimits struct {
+	// MaxDepth is the number of nested levels kept before values are replaced
+	// with TruncatedValue. Zero means no limit, although values nested deeper
+	// than an internal ceiling, such as cyclic ones, still fail to encode.
+	MaxDepth int
+	// MaxBytes is an approximate budget for keys and values. Once spent,
+	// strings are cut short and remaining entries dropped. Zero means no limit.
+	MaxBytes int
+}
+
+func SetEmplaceLimits(limits EmplaceLimits) {
+	emplaceLimits.Store(&limits)
+}
+
+func getEmplaceLimits() EmplaceLimits {
+	return *emplaceLimits.Load()
+}
+
+// redactedObject keeps struct field order when encoded, unlike a map.
+type redactedObject []redactedField
+
+type redactedField struct {
+	Key   string
+	Value any
+}
+
+type redactedList []any
+
+func (o *redactedObject) markTruncated() {
+	if n := len(*o); n > 0 && (*o)[n-1].Key == truncatedKey {
+		return
+	}
+	*o = append(*o, redactedField{Key: truncatedKey, Value: true})
+}
+
+func (o redactedObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, f := range o {
+		if err := addValue(enc, f.Key, f.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l redactedList) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, v := range l {
+		if err := appendValue(enc, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o redactedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func addValue(enc zapcore.ObjectEncoder, key string, val any) error {
+	switch v := val.(type) {
+	case redactedObject:
+		return enc.AddObject(key, v)
+	case redactedList:
+		return enc.AddArray(key, v)
+	case string:
+		enc.AddString(key, v)
+	case bool:
+		enc.AddBool(key, v)
+	case []byte:
+		enc.AddBinary(key, v)
+	default:
+		return enc.AddReflected(key, v)
+	}
+	return nil
+}
+
+func appendValue(enc zapcore.ArrayEncoder, val any) error {
+	switch v := val.(type) {
+	case redactedObject:
+		return enc.AppendObject(v)
+	case redactedList:
+		return enc.AppendArray(v)
+	case string:
+		enc.AppendString(v)
+	case bool:
+		enc.AppendBool(v)
+	default:
+		return enc.AppendReflected(v)
+	}
+	return nil
+}
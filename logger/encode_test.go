@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type node struct {
+	Name  string `json:"name"`
+	Child *node  `json:"child,omitempty"`
+}
+
+func TestEmplaceKVStructured(t *testing.T) {
+	val := struct {
+		ID      int       `json:"id"`
+		Tags    []string  `json:"tags"`
+		Created time.Time `json:"created"`
+		Nested  node      `json:"nested"`
+	}{
+		ID:      1,
+		Tags:    []string{"a", "b"},
+		Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Nested:  node{Name: "leaf"},
+	}
+
+	field := EmplaceKV("payload", val)
+	if field.Type != zapcore.ObjectMarshalerType {
+		t.Errorf("Expected object field, got type %v", field.Type)
+	}
+
+	expected := `{"payload":{"id":1,"tags":["a","b"],"created":"2024-01-02T03:04:05Z","nested":{"name":"leaf"}}}`
+	if got := encodeField(t, field); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
+
+func TestEmplaceKVMarshalError(t *testing.T) {
+	field := EmplaceKV("bad", map[string]any{"ch": make(chan int)})
+
+	got := encodeField(t, field)
+	if !strings.Contains(got, "failed to marshal") || !strings.Contains(got, "chan int") {
+		t.Errorf("Expected marshal error detail, got %s", got)
+	}
+}
+
+func TestEmplaceKVLimits(t *testing.T) {
+	defer SetEmplaceLimits(getEmplaceLimits())
+
+	SetEmplaceLimits(EmplaceLimits{MaxDepth: 2})
+	deep := node{Name: "1", Child: &node{Name: "2", Child: &node{Name: "3"}}}
+	got := encodeField(t, EmplaceKV("tree", deep))
+	if got != `{"tree":{"name":"1","child":{"name":"2","child":"[TRUNCATED]"}}}` {
+		t.Errorf("Expected depth truncation, got %s", got)
+	}
+
+	SetEmplaceLimits(EmplaceLimits{MaxBytes: 20})
+	got = encodeField(t, EmplaceKV("big", map[string]string{
+		"a": strings.Repeat("x", 10),
+		"b": strings.Repeat("y", 50),
+		"c": "dropped",
+	}))
+	if !strings.Contains(got, `"b":"yyyyyyyy[TRUNCATED]"`) {
+		t.Errorf("Expected long value to be cut, got %s", got)
+	}
+	if strings.Contains(got, "dropped") || !strings.Contains(got, `"_truncated":true`) {
+		t.Errorf("Expected remaining entries to be dropped, got %s", got)
+	}
+}
+
+func TestEmplaceKVCycle(t *testing.T) {
+	defer SetEmplaceLimits(getEmplaceLimits())
+	SetEmplaceLimits(EmplaceLimits{})
+
+	cyclic := &node{Name: "loop"}
+	cyclic.Child = cyclic
+	var self any
+	self = &self
+
+	for name, val := range map[string]any{"struct": cyclic, "interface": self} {
+		got := encodeField(t, EmplaceKV(name, val))
+		if !strings.Contains(got, "failed to marshal") || !strings.Contains(got, "cyclic") {
+			t.Errorf("Expected cycle error for %s, got %s", name, got)
+		}
+	}
+}
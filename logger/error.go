@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const maxErrorChain = 32
+
+// ErrorKind can be implemented by errors to control the error_kind field.
+type ErrorKind interface {
+	Kind() string
+}
+
+// StackTracer can be implemented by errors that captured a stack trace.
+type StackTracer interface {
+	Stack() []byte
+}
+
+// ErrorLog logs err as "error" alongside a stable "error_kind" and an
+// "error_chain" listing every wrapped or joined cause.
+func ErrorLog(err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+	return zap.Inline(errorInfo{err: err})
+}
+
+type errorInfo struct {
+	err error
+}
+
+func (e errorInfo) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("error", safeString(e.err, e.err.Error))
+	if isNilPointer(e.err) {
+		enc.AddString("error_kind", fmt.Sprintf("%T", e.err))
+		return nil
+	}
+	enc.AddString("error_kind", errorKind(e.err))
+	return enc.AddArray("error_chain", errorChain{err: e.err})
+}
+
+type errorChain struct {
+	err   error
+	depth int
+}
+
+func (c errorChain) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	err := c.err
+	for depth := c.depth; err != nil && depth < maxErrorChain; depth++ {
+		if encErr := enc.AppendObject(errorNode{err: err, depth: depth}); encErr != nil {
+			return encErr
+		}
+
+		// Joined members are nested under the node itself, and a typed nil
+		// cannot be unwrapped safely.
+		if _, ok := err.(interface{ Unwrap() []error }); ok || isNilPointer(err) {
+			return nil
+		}
+		err = errors.Unwrap(err)
+	}
+	return nil
+}
+
+type errorNode struct {
+	err   error
+	depth int
+}
+
+func (n errorNode) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("message", safeString(n.err, n.err.Error))
+	enc.AddString("type", fmt.Sprintf("%T", n.err))
+	if isNilPointer(n.err) {
+		return nil
+	}
+	if kind, ok := n.err.(ErrorKind); ok {
+		enc.AddString("kind", kind.Kind())
+	}
+	if tracer, ok := n.err.(StackTracer); ok {
+		enc.AddString("stack", string(tracer.Stack()))
+	}
+
+	if joined, ok := n.err.(interface{ Unwrap() []error }); ok {
+		return enc.AddArray("joined", joinedErrors{errs: joined.Unwrap(), depth: n.depth + 1})
+	}
+	return nil
+}
+
+type joinedErrors struct {
+	errs  []error
+	depth int
+}
+
+func (j joinedErrors) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, err := range j.errs {
+		if err == nil {
+			continue
+		}
+		if encErr := enc.AppendArray(errorChain{err: err, depth: j.depth}); encErr != nil {
+			return encErr
+		}
+	}
+	return nil
+}
+
+// errorKind returns the Kind of the outermost error implementing ErrorKind,
+// otherwise the type of the innermost cause, or "joined" when the chain ends
+// in several errors.
+func errorKind(err error) (kind string) {
+	defer func() {
+		// Unwrap or Kind may dereference a typed nil deeper in the chain.
+		if recover() != nil {
+			kind = fmt.Sprintf("%T", err)
+		}
+	}()
+	return resolveErrorKind(err)
+}
+
+func resolveErrorKind(err error) string {
+	var kind ErrorKind
+	if errors.As(err, &kind) {
+		return kind.Kind()
+	}
+
+	for i := 0; i < maxErrorChain; i++ {
+		if _, ok := err.(interface{ Unwrap() []error }); ok {
+			return "joined"
+		}
+		next := errors.Unwrap(err)
+		if next == nil {
+			break
+		}
+		err = next
+	}
+	return fmt.Sprintf("%T", err)
+}
+
+// isNilPointer reports whether err is a typed nil pointer, whose methods may
+// panic when called.
+func isNilPointer(err error) bool {
+	v := reflect.ValueOf(err)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
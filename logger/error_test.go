@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+type kindError struct{}
+
+func (kindError) Error() string { return "quota exceeded" }
+func (kindError) Kind() string  { return "quota" }
+func (kindError) Stack() []byte { return []byte("main.go:1") }
+
+func decodeField(t *testing.T, field zap.Field) map[string]interface{} {
+	t.Helper()
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(encodeField(t, field)), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func TestErrorLogChain(t *testing.T) {
+	err := fmt.Errorf("load config: %w", os.ErrNotExist)
+	decoded := decodeField(t, ErrorLog(err))
+
+	if decoded["error"] != "load config: file does not exist" {
+		t.Errorf("Unexpected error message %v", decoded["error"])
+	}
+	if decoded["error_kind"] != "*errors.errorString" {
+		t.Errorf("Expected kind of root cause, got %v", decoded["error_kind"])
+	}
+
+	chain, _ := decoded["error_chain"].([]interface{})
+	if len(chain) != 2 {
+		t.Fatalf("Expected 2 chain entries, got %v", decoded["error_chain"])
+	}
+	if root, _ := chain[1].(map[string]interface{}); root["message"] != "file does not exist" {
+		t.Errorf("Unexpected root cause %v", chain[1])
+	}
+}
+
+func TestErrorLogJoinedAndKind(t *testing.T) {
+	err := fmt.Errorf("batch: %w", errors.Join(errors.New("first"), kindError{}))
+	decoded := decodeField(t, ErrorLog(err))
+
+	if decoded["error_kind"] != "quota" {
+		t.Errorf("Expected kind from ErrorKind, got %v", decoded["error_kind"])
+	}
+
+	chain, _ := decoded["error_chain"].([]interface{})
+	if len(chain) != 2 {
+		t.Fatalf("Expected wrapper and joined node, got %v", chain)
+	}
+	joinedNode, _ := chain[1].(map[string]interface{})
+	members, _ := joinedNode["joined"].([]interface{})
+	if len(members) != 2 {
+		t.Fatalf("Expected 2 joined members, got %v", joinedNode)
+	}
+	second, _ := members[1].([]interface{})
+	member, _ := second[0].(map[string]interface{})
+	if member["kind"] != "quota" || member["stack"] != "main.go:1" {
+		t.Errorf("Expected kind and stack on member, got %v", member)
+	}
+}
+
+func TestErrorLogNil(t *testing.T) {
+	if got := encodeField(t, ErrorLog(nil)); got != "{}" {
+		t.Errorf("Expected nil error to be skipped, got %s", got)
+	}
+}
+
+type ptrError struct{ msg string }
+
+func (e *ptrError) Error() string { return e.msg }
+
+func TestErrorLogTypedNil(t *testing.T) {
+	var typedNil *ptrError
+
+	decoded := decodeField(t, ErrorLog(typedNil))
+	if decoded["error"] != "<nil>" || decoded["error_kind"] != "*logger.ptrError" {
+		t.Errorf("Expected typed nil to be logged safely, got %v", decoded)
+	}
+
+	decoded = decodeField(t, ErrorLog(fmt.Errorf("wrapped: %w", typedNil)))
+	chain, _ := decoded["error_chain"].([]interface{})
+	if len(chain) != 2 {
+		t.Fatalf("Expected typed nil to end the chain, got %v", decoded)
+	}
+	if last, _ := chain[1].(map[string]interface{}); last["message"] != "<nil>" {
+		t.Errorf("Expected typed nil cause, got %v", chain[1])
+	}
+
+	var buf bytes.Buffer
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: &buf}))
+	l.Error(context.Background(), "typed nil", ErrorLog(typedNil))
+	if !strings.Contains(buf.String(), `"error":"<nil>"`) {
+		t.Errorf("Expected entry to be written, got %s", buf.String())
+	}
+}
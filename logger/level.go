@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SetLevel changes the minimum level of l. On a named logger it sets the
+// override for that name only.
+func (l *Logger) SetLevel(level zapcore.Level) {
+	if l.name != "" {
+		l.levels.set(l.name, level)
+		return
+	}
+	l.level.SetLevel(level)
+}
+
+func (l *Logger) GetLevel() zapcore.Level {
+	return l.atomicLevel().Level()
+}
+
+// LevelHandler serves the current level as JSON on GET and changes it on PUT,
+// e.g. `curl -X PUT -d '{"level":"debug"}'`.
+func (l *Logger) LevelHandler() http.Handler {
+	if l.name == "" {
+		return l.level
+	}
+	return l.levels.getOrCreate(l.name, l.GetLevel())
+}
+
+func (l *Logger) atomicLevel() zap.AtomicLevel {
+	if l.name != "" {
+		if level, ok := l.levels.lookup(l.name); ok {
+			return level
+		}
+	}
+	return l.level
+}
+
+func SetLevel(level zapcore.Level) {
+	Default().SetLevel(level)
+}
+
+func GetLevel() zapcore.Level {
+	return Default().GetLevel()
+}
+
+func LevelHandler() http.Handler {
+	return Default().LevelHandler()
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSetLevel(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "level.log")
+	l := NewLogger(WithFile(logPath))
+	ctx := context.Background()
+
+	if l.GetLevel() != zapcore.InfoLevel {
+		t.Errorf("Expected info level, got %v", l.GetLevel())
+	}
+
+	l.Debug(ctx, "hidden debug")
+	l.SetLevel(zapcore.DebugLevel)
+	l.Debug(ctx, "visible debug")
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "hidden debug") {
+		t.Error("Expected debug entry to be dropped at info level")
+	}
+	if !strings.Contains(string(content), "visible debug") {
+		t.Error("Expected debug entry after SetLevel(debug)")
+	}
+}
+
+func TestLevelHandler(t *testing.T) {
+	l := NewLogger(WithFile(filepath.Join(t.TempDir(), "handler.log")))
+	server := httptest.NewServer(l.LevelHandler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200, got %d", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"level":"warn"}`))
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200, got %d", resp.StatusCode)
+	}
+
+	if l.GetLevel() != zapcore.WarnLevel {
+		t.Errorf("Expected warn level after PUT, got %v", l.GetLevel())
+	}
+}
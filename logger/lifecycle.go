@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type Buffer struct {
+	// Size is the number of bytes buffered before a write is forced.
+	// Zero uses zap's default of 256 kB.
+	Size int
+	// FlushInterval is how often buffered entries are flushed.
+	// Zero uses zap's default of 30 seconds.
+	FlushInterval time.Duration
+}
+
+// WithBuffer buffers writes to every sink, flushing periodically and on
+// Sync, Close and Fatal.
+func WithBuffer(buffer Buffer) Option {
+	return func(c *config) {
+		c.buffer = &buffer
+	}
+}
+
+func (l *Logger) Sync() error {
+	return l.zl.Sync()
+}
+
+// Close flushes buffered entries and releases the log file. It is safe to call
+// concurrently and more than once. Named children only flush, since the
+// resources belong to the root logger. The logger must not be used afterwards.
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return l.Sync()
+	}
+	return l.closer.close(l.Sync)
+}
+
+// closer releases the resources opened by NewLogger exactly once.
+type closer struct {
+	once sync.Once
+	fns  []func() error
+	err  error
+}
+
+func (c *closer) close(flush func() error) error {
+	c.once.Do(func() {
+		errs := []error{flush()}
+		for i := len(c.fns) - 1; i >= 0; i-- {
+			errs = append(errs, c.fns[i]())
+		}
+		c.err = errors.Join(errs...)
+	})
+	return c.err
+}
+
+func Sync() error {
+	return Default().Sync()
+}
+
+func Close() error {
+	return Default().Close()
+}
+
+// FlushOnSignal closes the default logger when one of signals (SIGINT and
+// SIGTERM by default) arrives and then re-raises the signal so the process
+// still terminates. Programs with their own shutdown handling should call
+// Close themselves instead. The returned func stops listening.
+func FlushOnSignal(signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	h := &flushHandler{
+		sigCh: make(chan os.Signal, 1),
+		done:  make(chan struct{}),
+	}
+	flushMu.Lock()
+	flushHandlers[h] = struct{}{}
+	flushMu.Unlock()
+	signal.Notify(h.sigCh, signals...)
+
+	go func() {
+		select {
+		case sig := <-h.sigCh:
+			_ = Close()
+			h.stop()
+			reraise(sig)
+		case <-h.done:
+		}
+	}()
+
+	return h.stop
+}
+
+// StopFlushOnSignal stops every handler started by FlushOnSignal, for code
+// that takes over signal handling and closes the logger itself.
+func StopFlushOnSignal() {
+	flushMu.Lock()
+	handlers := make([]*flushHandler, 0, len(flushHandlers))
+	for h := range flushHandlers {
+		handlers = append(handlers, h)
+	}
+	flushMu.Unlock()
+
+	for _, h := range handlers {
+		h.stop()
+	}
+}
+
+var (
+	flushMu       sync.Mutex
+	flushHandlers = map[*flushHandler]struct{}{}
+)
+
+type flushHandler struct {
+	sigCh chan os.Signal
+	done  chan struct{}
+	once  sync.Once
+}
+
+func (h *flushHandler) stop() {
+	h.once.Do(func() {
+		signal.Stop(h.sigCh)
+		close(h.done)
+
+		flushMu.Lock()
+		delete(flushHandlers, h)
+		flushMu.Unlock()
+	})
+}
+
+// reraise is replaced in tests.
+var reraise = reraiseSignal
+
+func reraiseSignal(sig os.Signal) {
+	if p, err := os.FindProcess(os.Getpid()); err == nil && p.Signal(sig) == nil {
+		return
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWithBufferSync(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "buffered.log")
+	l := NewLogger(WithFile(logPath), WithBuffer(Buffer{FlushInterval: time.Hour}))
+	defer l.Close()
+
+	l.Info(context.Background(), "buffered entry")
+
+	content, _ := os.ReadFile(logPath)
+	if strings.Contains(string(content), "buffered entry") {
+		t.Fatal("Expected entry to be buffered before Sync")
+	}
+
+	if err := l.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "buffered entry") {
+		t.Error("Expected entry to be flushed after Sync")
+	}
+}
+
+func TestClose(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "closed.log")
+	l := NewLogger(WithFile(logPath), WithBuffer(Buffer{FlushInterval: time.Hour}))
+
+	l.Info(context.Background(), "last words")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "last words") {
+		t.Error("Expected Close to flush buffered entries")
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("Expected second Close to be a no-op, got %v", err)
+	}
+}
+
+func TestCloseConcurrentAndNamed(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "shared.log")
+	l := NewLogger(WithFile(logPath), WithBuffer(Buffer{FlushInterval: time.Hour}))
+
+	child := l.Named("worker")
+	child.Info(context.Background(), "from child")
+	if err := child.Close(); err != nil {
+		t.Fatal(err)
+	}
+	l.Info(context.Background(), "root still open")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = l.Close()
+		}()
+	}
+	wg.Wait()
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range []string{"from child", "root still open"} {
+		if !strings.Contains(string(content), msg) {
+			t.Errorf("Expected %q to be flushed, got %s", msg, content)
+		}
+	}
+}
+
+func TestFlushOnSignalStop(t *testing.T) {
+	stop := FlushOnSignal()
+	stop()
+	stop()
+
+	FlushOnSignal()
+	StopFlushOnSignal()
+
+	flushMu.Lock()
+	defer flushMu.Unlock()
+	if len(flushHandlers) != 0 {
+		t.Errorf("Expected all handlers to be stopped, got %d", len(flushHandlers))
+	}
+}
+
+func TestFlushOnSignal(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "signal.log")
+	l := NewLogger(WithFile(logPath), WithBuffer(Buffer{FlushInterval: time.Hour}))
+
+	previous := Default()
+	SetDefault(l)
+	defer SetDefault(previous)
+
+	raised := make(chan os.Signal, 1)
+	reraise = func(sig os.Signal) { raised <- sig }
+	defer func() { reraise = reraiseSignal }()
+
+	stop := FlushOnSignal(syscall.SIGTERM)
+	defer stop()
+
+	Info(context.Background(), "flushed on signal")
+
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Signal(syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case sig := <-raised:
+		if sig != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM to be re-raised, got %v", sig)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected signal to be handled")
+	}
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "flushed on signal") {
+		t.Errorf("Expected buffered entry to be flushed, got %s", content)
+	}
+}
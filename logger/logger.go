@@ -2,8 +2,10 @@ package logger
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sync"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -14,104 +16,190 @@ const (
 	LogIDKey = "log_id"
 )
 
-var logger *zap.Logger
+var (
+	logger      *Logger
+	loggerMu    sync.RWMutex
+	defaultOnce sync.Once
+)
 
-func Init(logFile string) {
-	if err := os.MkdirAll("logs", 0755); err != nil {
-		panic(err)
+type Logger struct {
+	zl      *zap.Logger
+	core    zapcore.Core
+	zapOpts []zap.Option
+	// stacktrace mirrors the zap.AddStacktrace option for entries that bypass
+	// zl, such as slog records.
+	stacktrace zapcore.LevelEnabler
+	name       string
+	level      zap.AtomicLevel
+	levels     *namedLevels
+	drops      *dropCounter
+	closer     *closer
+	asyncs     []*asyncWriteSyncer
+}
+
+func NewLogger(opts ...Option) *Logger {
+	cfg := newConfig(opts...)
+
+	cores := make([]zapcore.Core, 0, len(cfg.sinks)+1)
+	if !cfg.noFile {
+		cores = append(cores, zapcore.NewCore(cfg.buildEncoder(), cfg.writeSyncer(newFileWriteSyncer(cfg)), zapcore.DebugLevel))
+	}
+	for _, sink := range cfg.sinks {
+		cores = append(cores, sink.core(cfg))
+	}
+	if cfg.redactor != nil {
+		for i := range cores {
+			cores[i] = newRedactCore(cores[i], cfg.redactor)
+		}
 	}
 
-	if logFile == "" {
-		logFile = "logs/logger.log"
+	drops := newDropCounter()
+	core := zapcore.NewTee(cores...)
+	if cfg.sampling != nil {
+		core = newSamplingCore(core, *cfg.sampling, drops)
 	}
-	rotateConfig := &lumberjack.Logger{
-		Filename:   logFile,
-		MaxSize:    100, // megabytes
-		MaxBackups: 3,
-		MaxAge:     28,   // days
-		Compress:   true, // compress rotated files
+	if cfg.rateLimit != nil {
+		core = newRateLimitCore(core, *cfg.rateLimit, drops)
 	}
 
-	encConfig := zap.NewProductionEncoderConfig()
-	encConfig.TimeKey = "timestamp"
-	encConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-	fileEncoder := zapcore.NewJSONEncoder(encConfig)
-	fileWS := zapcore.AddSync(rotateConfig)
-	logLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
-
-	logger = zap.New(
-		zapcore.NewCore(fileEncoder, fileWS, logLevel),
-		zap.AddCaller(),
-		zap.AddCallerSkip(1),
-		zap.AddStacktrace(zapcore.ErrorLevel),
-	)
-	defer logger.Sync()
-}
-
-func initConsoleLogger() {
-	logger = zap.New(
-		zapcore.NewCore(
-			zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
-			zapcore.AddSync(os.Stdout),
-			zap.NewAtomicLevelAt(zap.PanicLevel),
-		),
-	)
-}
-
-func getLogIDField(ctx context.Context) zap.Field {
-	logID := ctx.Value(LogIDKey)
-	if logID == nil {
-		return zap.String(LogIDKey, "unknown")
+	l := newCoreLogger(core, cfg.level, cfg)
+	l.levels.setAll(cfg.namedLevels)
+	l.drops = drops
+	l.closer = &closer{fns: cfg.closers}
+	l.asyncs = cfg.asyncs
+	return l
+}
+
+// newCoreLogger wraps an ungated core. The logger level, and any named level
+// overrides, are applied on top of it.
+func newCoreLogger(core zapcore.Core, level zapcore.Level, cfg *config) *Logger {
+	zapOpts := cfg.zapOptions()
+
+	l := &Logger{
+		core:       core,
+		zapOpts:    zapOpts,
+		stacktrace: cfg.stacktraceEnabler(),
+		level:      zap.NewAtomicLevelAt(level),
+		levels:     newNamedLevels(),
+		drops:      newDropCounter(),
 	}
-	return zap.String(LogIDKey, logID.(string))
+	l.zl = zap.New(newLevelCore(core, l.enabler()), zapOpts...)
+	return l
 }
 
-func Info(ctx context.Context, msg string, fields ...zap.Field) {
-	if logger == nil {
-		initConsoleLogger()
+func newFileWriteSyncer(cfg *config) zapcore.WriteSyncer {
+	if err := os.MkdirAll(filepath.Dir(cfg.logFile), 0755); err != nil {
+		panic(err)
 	}
 
-	allFields := append([]zap.Field{getLogIDField(ctx)}, fields...)
-	logger.Info(msg, allFields...)
+	rotateConfig := &lumberjack.Logger{
+		Filename:   cfg.logFile,
+		MaxSize:    cfg.rotation.MaxSize,
+		MaxBackups: cfg.rotation.MaxBackups,
+		MaxAge:     cfg.rotation.MaxAge,
+		Compress:   cfg.rotation.Compress,
+		LocalTime:  cfg.rotation.LocalTime,
+	}
+	cfg.closers = append(cfg.closers, rotateConfig.Close)
+
+	return zapcore.AddSync(rotateConfig)
 }
 
-func Warn(ctx context.Context, msg string, fields ...zap.Field) {
-	if logger == nil {
-		initConsoleLogger()
-	}
+func Init(logFile string, opts ...Option) {
+	SetDefault(NewLogger(append([]Option{WithFile(logFile)}, opts...)...))
+}
+
+func Default() *Logger {
+	defaultOnce.Do(func() {
+		loggerMu.Lock()
+		defer loggerMu.Unlock()
 
-	allFields := append([]zap.Field{getLogIDField(ctx)}, fields...)
-	logger.Warn(msg, allFields...)
+		if logger == nil {
+			logger = newEnvLogger()
+		}
+	})
+
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+
+	return logger
 }
 
-func Error(ctx context.Context, msg string, fields ...zap.Field) {
-	if logger == nil {
-		initConsoleLogger()
+func SetDefault(l *Logger) {
+	if l == nil {
+		return
 	}
 
-	allFields := append([]zap.Field{getLogIDField(ctx)}, fields...)
-	logger.Error(msg, allFields...)
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+
+	logger = l
 }
 
-func Fatal(ctx context.Context, msg string, fields ...zap.Field) {
-	if logger == nil {
-		initConsoleLogger()
+func (l *Logger) log(ctx context.Context, lvl zapcore.Level, msg string, fields []zap.Field) {
+	ce := l.zl.Check(lvl, msg)
+	if ce == nil {
+		return
 	}
 
-	allFields := append([]zap.Field{getLogIDField(ctx)}, fields...)
-	logger.Fatal(msg, allFields...)
+	ce.Write(append(contextFields(ctx), fields...)...)
+}
+
+func (l *Logger) Log(ctx context.Context, lvl zapcore.Level, msg string, fields ...zap.Field) {
+	l.log(ctx, lvl, msg, fields)
+}
+
+func (l *Logger) Debug(ctx context.Context, msg string, fields ...zap.Field) {
+	l.log(ctx, zapcore.DebugLevel, msg, fields)
+}
+
+func (l *Logger) Info(ctx context.Context, msg string, fields ...zap.Field) {
+	l.log(ctx, zapcore.InfoLevel, msg, fields)
+}
+
+func (l *Logger) Warn(ctx context.Context, msg string, fields ...zap.Field) {
+	l.log(ctx, zapcore.WarnLevel, msg, fields)
+}
+
+func (l *Logger) Error(ctx context.Context, msg string, fields ...zap.Field) {
+	l.log(ctx, zapcore.ErrorLevel, msg, fields)
+}
+
+func (l *Logger) Fatal(ctx context.Context, msg string, fields ...zap.Field) {
+	l.log(ctx, zapcore.FatalLevel, msg, fields)
+}
+
+func Log(ctx context.Context, lvl zapcore.Level, msg string, fields ...zap.Field) {
+	FromContext(ctx).log(ctx, lvl, msg, fields)
 }
 
-func ErrorLog(err error) zap.Field {
-	return zap.Error(err)
+func Debug(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).log(ctx, zapcore.DebugLevel, msg, fields)
+}
+
+func Info(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).log(ctx, zapcore.InfoLevel, msg, fields)
+}
+
+func Warn(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).log(ctx, zapcore.WarnLevel, msg, fields)
+}
+
+func Error(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).log(ctx, zapcore.ErrorLevel, msg, fields)
+}
+
+func Fatal(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).log(ctx, zapcore.FatalLevel, msg, fields)
 }
 
+// EmplaceKV logs val as a nested object under key, with redaction and the
+// limits set by SetEmplaceLimits applied.
 func EmplaceKV[T any](key string, val T) zap.Field {
-	valByte, err := json.Marshal(val)
-	valStr := string(valByte)
+	redacted, err := currentRedactor().redact(val, getEmplaceLimits())
 	if err != nil {
-		valStr = "failed to marshal"
+		return zap.NamedError(key, fmt.Errorf("failed to marshal: %w", err))
 	}
 
-	return zap.String(key, valStr)
+	return zap.Any(key, redacted)
 }
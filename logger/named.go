@@ -0,0 +1,174 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type namedLevels struct {
+	mu     sync.RWMutex
+	levels map[string]zap.AtomicLevel
+}
+
+func newNamedLevels() *namedLevels {
+	return &namedLevels{levels: map[string]zap.AtomicLevel{}}
+}
+
+func (n *namedLevels) set(name string, level zapcore.Level) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if atomicLevel, ok := n.levels[name]; ok {
+		atomicLevel.SetLevel(level)
+		return
+	}
+	n.levels[name] = zap.NewAtomicLevelAt(level)
+}
+
+func (n *namedLevels) getOrCreate(name string, level zapcore.Level) zap.AtomicLevel {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if atomicLevel, ok := n.levels[name]; ok {
+		return atomicLevel
+	}
+	atomicLevel := zap.NewAtomicLevelAt(level)
+	n.levels[name] = atomicLevel
+	return atomicLevel
+}
+
+func (n *namedLevels) setAll(levels map[string]zapcore.Level) {
+	for name, level := range levels {
+		n.set(name, level)
+	}
+}
+
+// lookup returns the level for name, falling back to its parents so that
+// "http" also covers "http.client".
+func (n *namedLevels) lookup(name string) (zap.AtomicLevel, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for name != "" {
+		if level, ok := n.levels[name]; ok {
+			return level, true
+		}
+
+		idx := strings.LastIndexByte(name, '.')
+		if idx < 0 {
+			break
+		}
+		name = name[:idx]
+	}
+	return zap.AtomicLevel{}, false
+}
+
+func (n *namedLevels) snapshot() map[string]zapcore.Level {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	levels := make(map[string]zapcore.Level, len(n.levels))
+	for name, level := range n.levels {
+		levels[name] = level.Level()
+	}
+	return levels
+}
+
+// parseNamedLevels parses "http=warn,cache=debug", skipping invalid entries.
+func parseNamedLevels(spec string) map[string]zapcore.Level {
+	levels := map[string]zapcore.Level{}
+	for _, entry := range strings.Split(spec, ",") {
+		name, levelStr, _ := strings.Cut(entry, "=")
+		name, levelStr = strings.TrimSpace(name), strings.TrimSpace(levelStr)
+		if name == "" || levelStr == "" {
+			continue
+		}
+
+		level, err := zapcore.ParseLevel(levelStr)
+		if err != nil {
+			continue
+		}
+		levels[name] = level
+	}
+	return levels
+}
+
+// enabler gates entries by the level of l's name, falling back to the root
+// level when no override is set.
+func (l *Logger) enabler() zapcore.LevelEnabler {
+	if l.name == "" {
+		return l.level
+	}
+	return zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+		if level, ok := l.levels.lookup(l.name); ok {
+			return level.Enabled(lvl)
+		}
+		return l.level.Enabled(lvl)
+	})
+}
+
+// Named returns a child logger whose entries carry name in the "logger" field
+// and whose minimum level can be set independently with SetLevel,
+// SetNamedLevel, WithNamedLevels or LOG_LEVELS. Nested names are joined
+// with a dot.
+func (l *Logger) Named(name string) *Logger {
+	if name == "" {
+		return l
+	}
+
+	child := *l
+	child.closer = nil
+	child.name = name
+	if l.name != "" {
+		child.name = l.name + "." + name
+	}
+	child.zl = zap.New(newLevelCore(l.core, child.enabler()), l.zapOpts...).Named(child.name)
+	return &child
+}
+
+func (l *Logger) Name() string {
+	return l.name
+}
+
+func (l *Logger) SetNamedLevel(name string, level zapcore.Level) {
+	l.levels.set(name, level)
+}
+
+func (l *Logger) NamedLevels() map[string]zapcore.Level {
+	return l.levels.snapshot()
+}
+
+func Named(name string) *Logger {
+	return Default().Named(name)
+}
+
+func SetNamedLevel(name string, level zapcore.Level) {
+	Default().SetNamedLevel(name, level)
+}
+
+type levelCore struct {
+	zapcore.Core
+	level zapcore.LevelEnabler
+}
+
+func newLevelCore(core zapcore.Core, level zapcore.LevelEnabler) zapcore.Core {
+	return &levelCore{Core: core, level: level}
+}
+
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.level.Enabled(lvl) && c.Core.Enabled(lvl)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.level.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
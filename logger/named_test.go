@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNamed(t *testing.T) {
+	observed := NewObserved(t)
+	root := observed.Logger()
+	root.SetLevel(zapcore.InfoLevel)
+	root.SetNamedLevel("http", zapcore.WarnLevel)
+	root.SetNamedLevel("cache", zapcore.DebugLevel)
+
+	ctx := context.Background()
+	http := root.Named("http")
+	client := http.Named("client")
+	cache := root.Named("cache")
+
+	root.Debug(ctx, "root debug")
+	root.Info(ctx, "root info")
+	http.Info(ctx, "http info")
+	http.Warn(ctx, "http warn")
+	client.Info(ctx, "client info")
+	cache.Debug(ctx, "cache debug")
+
+	var got []string
+	for _, entry := range observed.All() {
+		got = append(got, entry.Message)
+	}
+	expected := []string{"root info", "http warn", "cache debug"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if client.Name() != "http.client" || client.GetLevel() != zapcore.WarnLevel {
+		t.Errorf("Expected http.client to inherit http level, got %s at %v", client.Name(), client.GetLevel())
+	}
+
+	client.SetLevel(zapcore.DebugLevel)
+	if http.GetLevel() != zapcore.WarnLevel || client.GetLevel() != zapcore.DebugLevel {
+		t.Error("Expected SetLevel on a named logger to only change its own level")
+	}
+	if root.GetLevel() != zapcore.InfoLevel {
+		t.Errorf("Expected root level to be unchanged, got %v", root.GetLevel())
+	}
+}
+
+func TestNamedLevelHandler(t *testing.T) {
+	observed := NewObserved(t)
+	cache := observed.Logger().Named("cache")
+
+	server := httptest.NewServer(cache.LevelHandler())
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"level":"error"}`))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if cache.GetLevel() != zapcore.ErrorLevel || observed.Logger().GetLevel() != zapcore.DebugLevel {
+		t.Errorf("Expected only cache level to change, got cache=%v root=%v", cache.GetLevel(), observed.Logger().GetLevel())
+	}
+}
+
+func TestParseNamedLevels(t *testing.T) {
+	got := parseNamedLevels(" http=warn, cache=DEBUG,bad,empty=,=info,x=loud")
+	expected := map[string]zapcore.Level{"http": zapcore.WarnLevel, "cache": zapcore.DebugLevel}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestNamedLevelsFromEnv(t *testing.T) {
+	t.Setenv(EnvLogLevels, "http=error")
+
+	l := NewLogger(WithoutFile(), WithNamedLevels(map[string]zapcore.Level{"cache": zapcore.WarnLevel}))
+	expected := map[string]zapcore.Level{"http": zapcore.ErrorLevel, "cache": zapcore.WarnLevel}
+	if !reflect.DeepEqual(l.NamedLevels(), expected) {
+		t.Errorf("Expected %v, got %v", expected, l.NamedLevels())
+	}
+}
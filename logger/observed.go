@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type ObservedEntry struct {
+	Level   zapcore.Level
+	Message string
+	LogID   string
+	// Fields holds every field except the log ID, keyed by field name.
+	Fields  map[string]interface{}
+	Context []zapcore.Field
+}
+
+type ObservedLogs struct {
+	logger *Logger
+	logs   *observer.ObservedLogs
+}
+
+// NewObserved replaces the default logger with one that records entries of
+// every level in memory and restores the previous default when t finishes.
+// Tests using it must not run in parallel with other tests that log through
+// the default logger.
+func NewObserved(t testing.TB) *ObservedLogs {
+	t.Helper()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	observed := &ObservedLogs{
+		logger: newCoreLogger(core, zapcore.DebugLevel, newConfig()),
+		logs:   logs,
+	}
+
+	previous := Default()
+	SetDefault(observed.logger)
+	t.Cleanup(func() {
+		SetDefault(previous)
+	})
+
+	return observed
+}
+
+// Logger returns the observed logger for injection with NewContext.
+func (o *ObservedLogs) Logger() *Logger {
+	return o.logger
+}
+
+func (o *ObservedLogs) Len() int {
+	return o.logs.Len()
+}
+
+func (o *ObservedLogs) All() []ObservedEntry {
+	return toObservedEntries(o.logs.All())
+}
+
+func (o *ObservedLogs) FilterMessage(msg string) []ObservedEntry {
+	return toObservedEntries(o.logs.FilterMessage(msg).All())
+}
+
+func (o *ObservedLogs) FilterLevel(level zapcore.Level) []ObservedEntry {
+	return toObservedEntries(o.logs.FilterLevelExact(level).All())
+}
+
+func (o *ObservedLogs) FilterLogID(logID string) []ObservedEntry {
+	return toObservedEntries(o.logs.FilterField(zap.String(LogIDKey, logID)).All())
+}
+
+func (o *ObservedLogs) TakeAll() []ObservedEntry {
+	return toObservedEntries(o.logs.TakeAll())
+}
+
+func toObservedEntries(logged []observer.LoggedEntry) []ObservedEntry {
+	entries := make([]ObservedEntry, 0, len(logged))
+	for _, le := range logged {
+		fields := le.ContextMap()
+		logID, _ := fields[LogIDKey].(string)
+		delete(fields, LogIDKey)
+
+		entries = append(entries, ObservedEntry{
+			Level:   le.Level,
+			Message: le.Message,
+			LogID:   logID,
+			Fields:  fields,
+			Context: le.Context,
+		})
+	}
+	return entries
+}
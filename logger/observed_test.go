@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewObserved(t *testing.T) {
+	var previous *Logger
+	t.Run("capture", func(t *testing.T) {
+		previous = Default()
+		observed := NewObserved(t)
+
+		ctx := ContextWithLogID(context.Background(), "observed-id")
+		Debug(ctx, "debug entry")
+		Warn(ctx, "warn entry", zap.String("key", "value"), zap.Int("count", 2))
+		Info(context.Background(), "other entry")
+
+		if observed.Len() != 3 {
+			t.Fatalf("Expected 3 entries, got %d", observed.Len())
+		}
+
+		warns := observed.FilterLevel(zapcore.WarnLevel)
+		if len(warns) != 1 {
+			t.Fatalf("Expected 1 warn entry, got %d", len(warns))
+		}
+		entry := warns[0]
+		if entry.Message != "warn entry" || entry.LogID != "observed-id" {
+			t.Errorf("Unexpected entry %+v", entry)
+		}
+		if entry.Fields["key"] != "value" || entry.Fields["count"] != int64(2) {
+			t.Errorf("Unexpected fields %v", entry.Fields)
+		}
+		if _, ok := entry.Fields[LogIDKey]; ok {
+			t.Error("Expected log ID to be split out of fields")
+		}
+
+		if got := observed.FilterLogID("observed-id"); len(got) != 2 {
+			t.Errorf("Expected 2 entries for log ID, got %d", len(got))
+		}
+		if got := observed.FilterMessage("other entry"); len(got) != 1 || got[0].LogID != "unknown" {
+			t.Errorf("Unexpected entries for message: %+v", got)
+		}
+
+		if got := observed.TakeAll(); len(got) != 3 || observed.Len() != 0 {
+			t.Error("Expected TakeAll to drain captured entries")
+		}
+	})
+
+	if Default() != previous {
+		t.Error("Expected default logger to be restored after cleanup")
+	}
+}
@@ -0,0 +1,212 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/marcuspeh/go-tools/env"
+)
+
+const defaultLogFile = "logs/logger.log"
+
+type Encoding string
+
+const (
+	EncodingJSON    Encoding = "json"
+	EncodingConsole Encoding = "console"
+)
+
+type Rotation struct {
+	MaxSize    int // megabytes
+	MaxBackups int
+	MaxAge     int  // days
+	Compress   bool // compress rotated files
+	LocalTime  bool // use local time in backup file names
+}
+
+func DefaultRotation() Rotation {
+	return Rotation{
+		MaxSize:    100,
+		MaxBackups: 3,
+		MaxAge:     28,
+		Compress:   true,
+	}
+}
+
+type Option func(*config)
+
+type config struct {
+	logFile         string
+	noFile          bool
+	sinks           []Sink
+	rotation        Rotation
+	level           zapcore.Level
+	namedLevels     map[string]zapcore.Level
+	encoding        Encoding
+	timeFormat      string
+	encoder         zapcore.Encoder
+	caller          bool
+	stacktrace      bool
+	stacktraceLevel zapcore.Level
+	sampling        *Sampling
+	rateLimit       *RateLimit
+	redactor        *Redactor
+	buffer          *Buffer
+	async           *Async
+
+	// closers and asyncs collect the resources opened while building a Logger.
+	closers []func() error
+	asyncs  []*asyncWriteSyncer
+}
+
+func newConfig(opts ...Option) *config {
+	cfg := &config{
+		logFile:         defaultLogFile,
+		rotation:        DefaultRotation(),
+		level:           zapcore.InfoLevel,
+		namedLevels:     parseNamedLevels(env.GetEnvString(EnvLogLevels)),
+		encoding:        EncodingJSON,
+		caller:          true,
+		stacktrace:      true,
+		stacktraceLevel: zapcore.ErrorLevel,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+func (c *config) buildEncoder() zapcore.Encoder {
+	if c.encoder != nil {
+		return c.encoder
+	}
+	return c.newEncoder(c.encoding, false)
+}
+
+func (c *config) newEncoder(encoding Encoding, color bool) zapcore.Encoder {
+	encConfig := zap.NewProductionEncoderConfig()
+	encConfig.TimeKey = "timestamp"
+	encConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	if c.timeFormat != "" {
+		encConfig.EncodeTime = zapcore.TimeEncoderOfLayout(c.timeFormat)
+	}
+	if color {
+		encConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+
+	if encoding == EncodingConsole {
+		return zapcore.NewConsoleEncoder(encConfig)
+	}
+	return zapcore.NewJSONEncoder(encConfig)
+}
+
+func (c *config) writeSyncer(ws zapcore.WriteSyncer) zapcore.WriteSyncer {
+	if c.async != nil {
+		async := newAsyncWriteSyncer(ws, *c.async)
+		c.asyncs = append(c.asyncs, async)
+		c.closers = append(c.closers, async.Stop)
+		ws = async
+	}
+	if c.buffer == nil {
+		return ws
+	}
+
+	buffered := &zapcore.BufferedWriteSyncer{
+		WS:            ws,
+		Size:          c.buffer.Size,
+		FlushInterval: c.buffer.FlushInterval,
+	}
+	c.closers = append(c.closers, buffered.Stop)
+	return buffered
+}
+
+func (c *config) zapOptions() []zap.Option {
+	opts := []zap.Option{zap.AddCallerSkip(2)}
+	if c.caller {
+		opts = append(opts, zap.AddCaller())
+	}
+	if c.stacktrace {
+		opts = append(opts, zap.AddStacktrace(c.stacktraceLevel))
+	}
+	return opts
+}
+
+func (c *config) stacktraceEnabler() zapcore.LevelEnabler {
+	if !c.stacktrace {
+		return nil
+	}
+	return c.stacktraceLevel
+}
+
+func WithFile(logFile string) Option {
+	return func(c *config) {
+		if logFile != "" {
+			c.logFile = logFile
+		}
+	}
+}
+
+func WithoutFile() Option {
+	return func(c *config) {
+		c.noFile = true
+	}
+}
+
+func WithRotation(rotation Rotation) Option {
+	return func(c *config) {
+		c.rotation = rotation
+	}
+}
+
+func WithLevel(level zapcore.Level) Option {
+	return func(c *config) {
+		c.level = level
+	}
+}
+
+// WithNamedLevels sets the minimum level of named child loggers, overriding
+// LOG_LEVELS for the given names.
+func WithNamedLevels(levels map[string]zapcore.Level) Option {
+	return func(c *config) {
+		for name, level := range levels {
+			c.namedLevels[name] = level
+		}
+	}
+}
+
+func WithEncoding(encoding Encoding) Option {
+	return func(c *config) {
+		c.encoding = encoding
+	}
+}
+
+func WithTimeFormat(layout string) Option {
+	return func(c *config) {
+		c.timeFormat = layout
+	}
+}
+
+func WithEncoder(encoder zapcore.Encoder) Option {
+	return func(c *config) {
+		c.encoder = encoder
+	}
+}
+
+func WithCaller(enabled bool) Option {
+	return func(c *config) {
+		c.caller = enabled
+	}
+}
+
+func WithStacktrace(level zapcore.Level) Option {
+	return func(c *config) {
+		c.stacktrace = true
+		c.stacktraceLevel = level
+	}
+}
+
+func WithoutStacktrace() Option {
+	return func(c *config) {
+		c.stacktrace = false
+	}
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewLoggerCreatesLogFileDir(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "nested", "dir", "app.log")
+
+	l := NewLogger(WithFile(logPath))
+	l.Info(context.Background(), "hello")
+
+	if _, err := os.Stat(logPath); err != nil {
+		t.Fatalf("Expected log file to be created in nested dir: %v", err)
+	}
+}
+
+func TestNewLoggerOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []Option
+		check func(t *testing.T, line string)
+	}{
+		{
+			name: "json default",
+			check: func(t *testing.T, line string) {
+				var entry map[string]interface{}
+				if err := json.Unmarshal([]byte(line), &entry); err != nil {
+					t.Fatalf("Expected JSON line, got %s", line)
+				}
+				if _, ok := entry["timestamp"]; !ok {
+					t.Errorf("Expected timestamp key in %s", line)
+				}
+				if _, ok := entry["caller"]; !ok {
+					t.Errorf("Expected caller key in %s", line)
+				}
+			},
+		},
+		{
+			name: "console encoding",
+			opts: []Option{WithEncoding(EncodingConsole)},
+			check: func(t *testing.T, line string) {
+				if strings.HasPrefix(line, "{") {
+					t.Errorf("Expected console line, got %s", line)
+				}
+				if !strings.Contains(line, "hello") {
+					t.Errorf("Expected message in %s", line)
+				}
+			},
+		},
+		{
+			name: "time format and no caller",
+			opts: []Option{WithTimeFormat("2006"), WithCaller(false)},
+			check: func(t *testing.T, line string) {
+				var entry map[string]interface{}
+				if err := json.Unmarshal([]byte(line), &entry); err != nil {
+					t.Fatalf("Expected JSON line, got %s", line)
+				}
+				if ts, _ := entry["timestamp"].(string); len(ts) != 4 {
+					t.Errorf("Expected year-only timestamp, got %v", entry["timestamp"])
+				}
+				if _, ok := entry["caller"]; ok {
+					t.Errorf("Expected no caller key in %s", line)
+				}
+			},
+		},
+		{
+			name: "debug level",
+			opts: []Option{WithLevel(zapcore.DebugLevel), WithRotation(Rotation{MaxSize: 1})},
+			check: func(t *testing.T, line string) {
+				if !strings.Contains(line, "hello") {
+					t.Errorf("Expected message in %s", line)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPath := filepath.Join(t.TempDir(), "app.log")
+			l := NewLogger(append([]Option{WithFile(logPath)}, tt.opts...)...)
+			l.Info(context.Background(), "hello")
+
+			content, err := os.ReadFile(logPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, strings.TrimSpace(string(content)))
+		})
+	}
+}
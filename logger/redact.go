@@ -0,0 +1,567 @@
+package logger
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	RedactedValue = "[REDACTED]"
+
+	// Struct tag values understood by the redactor, e.g. `log:"redact"`.
+	logTagKey    = "log"
+	logTagRedact = "redact"
+	logTagOmit   = "-"
+
+	maxRedactDepth = 32
+
+	// maxWalkDepth bounds recursion regardless of EmplaceLimits so that
+	// cyclic values fail instead of overflowing the stack.
+	maxWalkDepth = 1000
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonNumberType    = reflect.TypeOf(json.Number(""))
+
+	redactor atomic.Pointer[Redactor]
+
+	identifierFields   = map[string]bool{LogIDKey: true}
+	identifierFieldsMu sync.RWMutex
+)
+
+func init() {
+	redactor.Store(NewRedactor(DefaultRedactionConfig()))
+}
+
+type ValuePattern struct {
+	Regexp *regexp.Regexp
+	// Match optionally confirms a regexp match before it is redacted, e.g. a
+	// Luhn check for card numbers.
+	Match func(s string) bool
+}
+
+type RedactionConfig struct {
+	KeyPatterns   []*regexp.Regexp
+	ValuePatterns []ValuePattern
+	Replacement   string
+}
+
+func DefaultRedactionConfig() RedactionConfig {
+	return RedactionConfig{
+		KeyPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)(password|passwd|secret|token|api[_-]?key|authorization|cookie|email)`),
+		},
+		ValuePatterns: []ValuePattern{
+			{Regexp: regexp.MustCompile(`(?i)bearer\s+[a-z0-9\-._~+/]+=*`)},
+			{Regexp: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`), Match: luhnValid},
+			{Regexp: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+		},
+		Replacement: RedactedValue,
+	}
+}
+
+type Redactor struct {
+	keys        []*regexp.Regexp
+	values      []ValuePattern
+	replacement string
+}
+
+func NewRedactor(cfg RedactionConfig) *Redactor {
+	replacement := cfg.Replacement
+	if replacement == "" {
+		replacement = RedactedValue
+	}
+
+	return &Redactor{
+		keys:        cfg.KeyPatterns,
+		values:      cfg.ValuePatterns,
+		replacement: replacement,
+	}
+}
+
+// SetRedactor replaces the redactor used by EmplaceKV. A nil redactor only
+// applies `log` struct tags.
+func SetRedactor(r *Redactor) {
+	if r == nil {
+		r = &Redactor{replacement: RedactedValue}
+	}
+	redactor.Store(r)
+}
+
+func currentRedactor() *Redactor {
+	return redactor.Load()
+}
+
+// WithRedaction applies r to every field and message written by the logger.
+func WithRedaction(r *Redactor) Option {
+	return func(c *config) {
+		c.redactor = r
+	}
+}
+
+func (r *Redactor) matchKey(key string) bool {
+	for _, pattern := range r.keys {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) redactString(s string) string {
+	for _, pattern := range r.values {
+		s = pattern.Regexp.ReplaceAllStringFunc(s, func(match string) string {
+			if pattern.Match != nil && !pattern.Match(match) {
+				return match
+			}
+			return r.replacement
+		})
+	}
+	return s
+}
+
+// Redact converts val into a tree of plain values with `log` struct tags, key
+// patterns and value patterns applied. Values nested deeper than the default
+// max depth are replaced with TruncatedValue.
+func (r *Redactor) Redact(val any) (any, error) {
+	return r.redact(val, EmplaceLimits{MaxDepth: maxRedactDepth})
+}
+
+func (r *Redactor) redact(val any, limits EmplaceLimits) (any, error) {
+	w := &walker{redactor: r, limits: limits, remaining: limits.MaxBytes}
+	return w.value(reflect.ValueOf(val), 0)
+}
+
+type walker struct {
+	redactor  *Redactor
+	limits    EmplaceLimits
+	remaining int
+	level     int
+}
+
+// spend charges n bytes against the size budget and reports whether the
+// budget still allows the value.
+func (w *walker) spend(n int) bool {
+	if w.limits.MaxBytes <= 0 {
+		return true
+	}
+	if w.remaining <= 0 {
+		return false
+	}
+	w.remaining -= n
+	return true
+}
+
+func (w *walker) exhausted() bool {
+	return w.limits.MaxBytes > 0 && w.remaining <= 0
+}
+
+func (w *walker) value(v reflect.Value, depth int) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	w.level++
+	defer func() { w.level-- }()
+	if w.level > maxWalkDepth {
+		return nil, fmt.Errorf("exceeded max depth %d, value may be cyclic", maxWalkDepth)
+	}
+
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil, nil
+	}
+	// Numbers decoded from a json.Marshaler stay numeric.
+	if v.Type() == jsonNumberType {
+		return w.number(json.Number(v.String())), nil
+	}
+	// Marshalers take precedence as in encoding/json, pointer receivers only
+	// when the value is addressable.
+	if m, ok := asMarshaler(v, jsonMarshalerType); ok {
+		return w.marshaler(m.(json.Marshaler), depth)
+	}
+	if m, ok := asMarshaler(v, textMarshalerType); ok {
+		text, err := m.(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return w.truncateString(w.redactor.redactString(string(text))), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		if w.limits.MaxDepth > 0 && depth >= w.limits.MaxDepth {
+			return TruncatedValue, nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return w.value(v.Elem(), depth)
+	case reflect.Struct:
+		obj := redactedObject{}
+		if err := w.structFields(v, depth, &obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case reflect.Map:
+		return w.mapEntries(v, depth)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return w.truncateBytes(v.Bytes()), nil
+		}
+		return w.list(v, depth)
+	case reflect.Array:
+		return w.list(v, depth)
+	case reflect.String:
+		return w.truncateString(w.redactor.redactString(v.String())), nil
+	case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return nil, &json.UnsupportedTypeError{Type: v.Type()}
+	default:
+		if !v.CanInterface() {
+			return nil, nil
+		}
+		w.spend(8)
+		return v.Interface(), nil
+	}
+}
+
+func (w *walker) number(n json.Number) any {
+	w.spend(8)
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return n.String()
+}
+
+func asMarshaler(v reflect.Value, marshalerType reflect.Type) (any, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+	if v.Type().Implements(marshalerType) {
+		return v.Interface(), true
+	}
+	if v.Kind() != reflect.Pointer && v.CanAddr() && reflect.PointerTo(v.Type()).Implements(marshalerType) {
+		return v.Addr().Interface(), true
+	}
+	return nil, false
+}
+
+func (w *walker) truncateString(s string) string {
+	if w.limits.MaxBytes <= 0 {
+		return s
+	}
+	if len(s) > w.remaining {
+		s = s[:max(w.remaining, 0)] + TruncatedValue
+	}
+	w.spend(len(s))
+	return s
+}
+
+func (w *walker) truncateBytes(b []byte) []byte {
+	if w.limits.MaxBytes > 0 && len(b) > w.remaining {
+		b = b[:max(w.remaining, 0)]
+	}
+	w.spend(len(b))
+	return b
+}
+
+func (w *walker) marshaler(m json.Marshaler, depth int) (any, error) {
+	raw, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return w.value(reflect.ValueOf(generic), depth)
+}
+
+func (w *walker) structFields(v reflect.Value, depth int, obj *redactedObject) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		logTag := field.Tag.Get(logTagKey)
+		if logTag == logTagOmit {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		fieldVal := v.Field(i)
+		if field.Anonymous && name == "" {
+			embedded := fieldVal
+			if embedded.Kind() == reflect.Pointer {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := w.structFields(embedded, depth, obj); err != nil {
+					return err
+				}
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(opts, "omitempty") && fieldVal.IsZero() {
+			continue
+		}
+
+		if !w.spend(len(name)) {
+			obj.markTruncated()
+			return nil
+		}
+		if logTag == logTagRedact || w.redactor.matchKey(name) {
+			*obj = append(*obj, redactedField{Key: name, Value: w.redactor.replacement})
+			continue
+		}
+
+		val, err := w.value(fieldVal, depth+1)
+		if err != nil {
+			return err
+		}
+		*obj = append(*obj, redactedField{Key: name, Value: val})
+	}
+	return nil
+}
+
+func (w *walker) mapEntries(v reflect.Value, depth int) (any, error) {
+	if v.IsNil() {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, v.Len())
+	values := make(map[string]reflect.Value, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := mapKey(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		values[key] = iter.Value()
+	}
+	sort.Strings(keys)
+
+	obj := make(redactedObject, 0, len(keys))
+	for _, key := range keys {
+		if !w.spend(len(key)) {
+			obj.markTruncated()
+			break
+		}
+		if w.redactor.matchKey(key) {
+			obj = append(obj, redactedField{Key: key, Value: w.redactor.replacement})
+			continue
+		}
+
+		val, err := w.value(values[key], depth+1)
+		if err != nil {
+			return nil, err
+		}
+		obj = append(obj, redactedField{Key: key, Value: val})
+	}
+	return obj, nil
+}
+
+// mapKey names a map key the way encoding/json does.
+func mapKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if m, ok := asMarshaler(k, textMarshalerType); ok {
+		if k.Kind() == reflect.Pointer && k.IsNil() {
+			return "", nil
+		}
+		text, err := m.(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	return fmt.Sprint(k.Interface()), nil
+}
+
+func (w *walker) list(v reflect.Value, depth int) (any, error) {
+	list := make(redactedList, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if w.exhausted() {
+			list = append(list, TruncatedValue)
+			break
+		}
+
+		val, err := w.value(v.Index(i), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, val)
+	}
+	return list, nil
+}
+
+// RegisterIdentifierField exempts fields named names from redaction, so that
+// correlation IDs which happen to look like card numbers stay intact. The
+// log ID field is always exempt.
+func RegisterIdentifierField(names ...string) {
+	identifierFieldsMu.Lock()
+	defer identifierFieldsMu.Unlock()
+
+	for _, name := range names {
+		identifierFields[name] = true
+	}
+}
+
+func isIdentifierField(name string) bool {
+	identifierFieldsMu.RLock()
+	defer identifierFieldsMu.RUnlock()
+
+	return identifierFields[name]
+}
+
+func (r *Redactor) redactField(f zapcore.Field) zapcore.Field {
+	if isIdentifierField(f.Key) {
+		return f
+	}
+	if r.matchKey(f.Key) {
+		return zap.String(f.Key, r.replacement)
+	}
+
+	switch f.Type {
+	case zapcore.StringType:
+		f.String = r.redactString(f.String)
+	case zapcore.ErrorType:
+		err := f.Interface.(error)
+		return zap.String(f.Key, r.redactString(safeString(err, err.Error)))
+	case zapcore.StringerType:
+		stringer := f.Interface.(fmt.Stringer)
+		return zap.String(f.Key, r.redactString(safeString(stringer, stringer.String)))
+	case zapcore.InlineMarshalerType:
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+		if tree, err := r.Redact(enc.Fields); err == nil {
+			if obj, ok := tree.(redactedObject); ok {
+				return zap.Inline(obj)
+			}
+		}
+	case zapcore.ByteStringType:
+		return zap.ByteString(f.Key, []byte(r.redactString(string(f.Interface.([]byte)))))
+	case zapcore.ReflectType:
+		if tree, err := r.Redact(f.Interface); err == nil {
+			return zap.Any(f.Key, tree)
+		}
+	case zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType:
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+		if tree, err := r.Redact(enc.Fields[f.Key]); err == nil {
+			return zap.Any(f.Key, tree)
+		}
+	}
+	return f
+}
+
+func (r *Redactor) redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		redacted[i] = r.redactField(f)
+	}
+	return redacted
+}
+
+// safeString calls fn, reporting "<nil>" for a typed nil receiver the way zap
+// encodes such errors and stringers.
+func safeString(v any, fn func() string) (s string) {
+	defer func() {
+		if p := recover(); p != nil {
+			if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+				s = "<nil>"
+				return
+			}
+			s = fmt.Sprintf("PANIC=%v", p)
+		}
+	}()
+	return fn()
+}
+
+type redactCore struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+func newRedactCore(core zapcore.Core, r *Redactor) zapcore.Core {
+	return &redactCore{Core: core, redactor: r}
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{
+		Core:     c.Core.With(c.redactor.redactFields(fields)),
+		redactor: c.redactor,
+	}
+}
+
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.redactor.redactString(ent.Message)
+	return c.Core.Write(ent, c.redactor.redactFields(fields))
+}
+
+func luhnValid(s string) bool {
+	sum, count := 0, 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		digit := int(c - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+		count++
+	}
+	return count >= 13 && sum%10 == 0
+}
@@ -0,0 +1,204 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+type credentials struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+	PIN      string `json:"pin" log:"redact"`
+	Internal string `log:"-"`
+	Note     string `json:"note,omitempty"`
+}
+
+type account struct {
+	credentials
+	ID    int               `json:"id"`
+	Token string            `json:"auth_token"`
+	Meta  map[string]string `json:"meta"`
+}
+
+func TestEmplaceKVRedaction(t *testing.T) {
+	val := account{
+		credentials: credentials{User: "alice", Password: "hunter2", PIN: "1234", Internal: "x"},
+		ID:          7,
+		Token:       "abc",
+		Meta:        map[string]string{"header": "Bearer abc.def", "card": "4111 1111 1111 1111", "order": "1234567890123"},
+	}
+
+	field := EmplaceKV("account", val)
+	expected := `{"account":{"user":"alice","password":"[REDACTED]","pin":"[REDACTED]","id":7,"auth_token":"[REDACTED]",` +
+		`"meta":{"card":"[REDACTED]","header":"[REDACTED]","order":"1234567890123"}}}`
+	if got := encodeField(t, field); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
+
+func TestSetRedactor(t *testing.T) {
+	defer SetRedactor(NewRedactor(DefaultRedactionConfig()))
+
+	SetRedactor(nil)
+	field := EmplaceKV("creds", credentials{User: "bob", Password: "pw", PIN: "1"})
+	if got := encodeField(t, field); got != `{"creds":{"user":"bob","password":"pw","pin":"[REDACTED]"}}` {
+		t.Errorf("Expected only tag redaction, got %s", got)
+	}
+
+	SetRedactor(NewRedactor(RedactionConfig{
+		KeyPatterns: []*regexp.Regexp{regexp.MustCompile(`^user$`)},
+		Replacement: "***",
+	}))
+	field = EmplaceKV("creds", credentials{User: "bob", Password: "pw"})
+	if got := encodeField(t, field); got != `{"creds":{"user":"***","password":"pw","pin":"***"}}` {
+		t.Errorf("Expected custom redaction, got %s", got)
+	}
+}
+
+func TestWithRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: &buf}),
+		WithRedaction(NewRedactor(DefaultRedactionConfig())))
+
+	l.Info(context.Background(), "login for bob@example.com",
+		zap.String("api_key", "secret-value"),
+		zap.String("header", "Bearer abc123"),
+		zap.Any("creds", credentials{User: "bob", Password: "pw"}),
+	)
+
+	out := buf.String()
+	for _, leaked := range []string{"bob@example.com", "secret-value", "abc123", `"pw"`} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("Expected %q to be redacted, got %s", leaked, out)
+		}
+	}
+	if !strings.Contains(out, `"user":"bob"`) {
+		t.Errorf("Expected non-sensitive fields to be kept, got %s", out)
+	}
+}
+
+func TestLuhnValid(t *testing.T) {
+	if !luhnValid("4111-1111-1111-1111") {
+		t.Error("Expected test card number to pass Luhn check")
+	}
+	if luhnValid("1234567890123") {
+		t.Error("Expected arbitrary digits to fail Luhn check")
+	}
+}
+
+type testStringer string
+
+func (s testStringer) String() string {
+	return string(s)
+}
+
+type nilStringer struct{ name string }
+
+func (s *nilStringer) String() string {
+	return s.name
+}
+
+func TestWithRedactionErrorAndStringer(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: &buf}),
+		WithRedaction(NewRedactor(DefaultRedactionConfig())))
+
+	err := fmt.Errorf("auth for bob@example.com: %w", errors.New("Bearer sekrit123 rejected"))
+	var typedNil *nilStringer
+	l.Info(context.Background(), "failed",
+		zap.Error(err),
+		zap.Stringer("who", testStringer("bob@example.com")),
+		zap.Stringer("nil", typedNil),
+		ErrorLog(err),
+	)
+
+	out := buf.String()
+	for _, leaked := range []string{"bob@example.com", "sekrit123"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("Expected %q to be redacted, got %s", leaked, out)
+		}
+	}
+	for _, kept := range []string{`"who":"[REDACTED]"`, `"nil":"<nil>"`, `"error_kind":"*errors.errorString"`, `"error_chain":[`} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("Expected %s in output, got %s", kept, out)
+		}
+	}
+}
+
+type textKey struct{ a, b string }
+
+func (k textKey) MarshalText() ([]byte, error) {
+	return []byte(k.a + "/" + k.b), nil
+}
+
+type host struct {
+	IP    net.IP            `json:"ip"`
+	Ports map[textKey]int   `json:"ports"`
+	Tags  map[string]string `json:"tags"`
+}
+
+func TestEmplaceKVTextMarshaler(t *testing.T) {
+	val := host{
+		IP:    net.ParseIP("10.0.0.1"),
+		Ports: map[textKey]int{{"tcp", "http"}: 80},
+		Tags:  map[string]string{"env": "prod"},
+	}
+
+	field := EmplaceKV("host", val)
+	expected := `{"host":{"ip":"10.0.0.1","ports":{"tcp/http":80},"tags":{"env":"prod"}}}`
+	if got := encodeField(t, field); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	raw, _ := json.Marshal(val)
+	if got := encodeField(t, EmplaceKV("host", val)); got != `{"host":`+string(raw)+`}` {
+		t.Errorf("Expected output to match json.Marshal %s, got %s", raw, got)
+	}
+}
+
+func TestWithRedactionIdentifierFields(t *testing.T) {
+	RegisterIdentifierField("request_id")
+
+	var buf bytes.Buffer
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: &buf}),
+		WithRedaction(NewRedactor(DefaultRedactionConfig())))
+
+	// Luhn-valid digit runs, as produced by e.g. snowflake IDs.
+	const id = "4111111111111111"
+	ctx := ContextWithLogID(context.Background(), id)
+	l.Info(ctx, "correlated", zap.String("request_id", id), zap.String("other", id))
+
+	out := buf.String()
+	for _, kept := range []string{`"log_id":"` + id + `"`, `"request_id":"` + id + `"`, `"other":"[REDACTED]"`} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("Expected %s in output, got %s", kept, out)
+		}
+	}
+}
+
+type counter struct{}
+
+func (counter) MarshalJSON() ([]byte, error) {
+	return []byte(`{"count":5,"ratio":1.5,"list":[1,2],"huge":123456789012345678901234567890}`), nil
+}
+
+type withCounter struct {
+	N counter `json:"n"`
+}
+
+func TestEmplaceKVMarshalerNumbers(t *testing.T) {
+	field := EmplaceKV("k", withCounter{})
+	expected := `{"k":{"n":{"count":5,"huge":1.2345678901234568e+29,"list":[1,2],"ratio":1.5}}}`
+	if got := encodeField(t, field); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,203 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	maxRateLimitKeys   = 4096
+	maxDroppedMessages = 1024
+
+	// OtherMessages collects drops and rate limit windows of messages beyond
+	// the tracked maximum.
+	OtherMessages = "[other]"
+)
+
+type Sampling struct {
+	Tick       time.Duration
+	First      int
+	Thereafter int
+}
+
+type RateLimit struct {
+	Limit    int
+	Interval time.Duration
+}
+
+type Stats struct {
+	SampledDropped uint64
+	RateLimited    uint64
+	// DroppedByMessage counts suppressed entries per log message.
+	DroppedByMessage map[string]uint64
+}
+
+func WithSampling(sampling Sampling) Option {
+	return func(c *config) {
+		c.sampling = &sampling
+	}
+}
+
+// WithRateLimit allows at most Limit entries with the same message per
+// Interval, on top of any sampling.
+func WithRateLimit(rateLimit RateLimit) Option {
+	return func(c *config) {
+		c.rateLimit = &rateLimit
+	}
+}
+
+func (l *Logger) Stats() Stats {
+	return l.drops.snapshot()
+}
+
+func GetStats() Stats {
+	return Default().Stats()
+}
+
+type dropCounter struct {
+	sampled     atomic.Uint64
+	rateLimited atomic.Uint64
+
+	mu        sync.Mutex
+	byMessage map[string]uint64
+}
+
+func newDropCounter() *dropCounter {
+	return &dropCounter{byMessage: map[string]uint64{}}
+}
+
+func (d *dropCounter) record(counter *atomic.Uint64, msg string) {
+	counter.Add(1)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.byMessage[msg]; !ok && len(d.byMessage) >= maxDroppedMessages {
+		msg = OtherMessages
+	}
+	d.byMessage[msg]++
+}
+
+func (d *dropCounter) snapshot() Stats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	byMessage := make(map[string]uint64, len(d.byMessage))
+	for msg, count := range d.byMessage {
+		byMessage[msg] = count
+	}
+
+	return Stats{
+		SampledDropped:   d.sampled.Load(),
+		RateLimited:      d.rateLimited.Load(),
+		DroppedByMessage: byMessage,
+	}
+}
+
+func newSamplingCore(core zapcore.Core, sampling Sampling, drops *dropCounter) zapcore.Core {
+	tick := sampling.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+
+	return zapcore.NewSamplerWithOptions(core, tick, sampling.First, sampling.Thereafter,
+		zapcore.SamplerHook(func(ent zapcore.Entry, dec zapcore.SamplingDecision) {
+			if dec&zapcore.LogDropped != 0 {
+				drops.record(&drops.sampled, ent.Message)
+			}
+		}),
+	)
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+type rateLimiter struct {
+	limit    int
+	interval time.Duration
+
+	mu      sync.Mutex
+	windows map[string]*rateWindow
+	// lastSweep limits evictExpired to once per interval, since no window
+	// can expire sooner than that after a sweep.
+	lastSweep time.Time
+}
+
+func (r *rateLimiter) allow(msg string, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.windows[msg]
+	if !ok && len(r.windows) >= maxRateLimitKeys && now.Sub(r.lastSweep) >= r.interval {
+		r.evictExpired(now)
+	}
+	// Messages that still do not fit share a single window.
+	if !ok && len(r.windows) >= maxRateLimitKeys {
+		msg = OtherMessages
+		w, ok = r.windows[msg]
+	}
+	if !ok || now.Sub(w.start) >= r.interval {
+		r.windows[msg] = &rateWindow{start: now, count: 1}
+		return true
+	}
+
+	w.count++
+	return w.count <= r.limit
+}
+
+func (r *rateLimiter) evictExpired(now time.Time) {
+	r.lastSweep = now
+	for msg, w := range r.windows {
+		if now.Sub(w.start) >= r.interval {
+			delete(r.windows, msg)
+		}
+	}
+}
+
+type rateLimitCore struct {
+	zapcore.Core
+	limiter *rateLimiter
+	drops   *dropCounter
+}
+
+func newRateLimitCore(core zapcore.Core, rateLimit RateLimit, drops *dropCounter) zapcore.Core {
+	interval := rateLimit.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	return &rateLimitCore{
+		Core: core,
+		limiter: &rateLimiter{
+			limit:    rateLimit.Limit,
+			interval: interval,
+			windows:  map[string]*rateWindow{},
+		},
+		drops: drops,
+	}
+}
+
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{
+		Core:    c.Core.With(fields),
+		limiter: c.limiter,
+		drops:   c.drops,
+	}
+}
+
+func (c *rateLimitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	if !c.limiter.allow(ent.Message, ent.Time) {
+		c.drops.record(&c.drops.rateLimited, ent.Message)
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithSampling(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: &buf}),
+		WithSampling(Sampling{Tick: time.Minute, First: 2, Thereafter: 3}))
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		l.Info(ctx, "hot loop")
+	}
+
+	// First 2 pass, then every 3rd of the remaining 8 (5th and 8th overall).
+	if got := strings.Count(buf.String(), "hot loop"); got != 4 {
+		t.Errorf("Expected 4 sampled entries, got %d", got)
+	}
+
+	stats := l.Stats()
+	if stats.SampledDropped != 6 {
+		t.Errorf("Expected 6 sampled drops, got %d", stats.SampledDropped)
+	}
+	if stats.DroppedByMessage["hot loop"] != 6 {
+		t.Errorf("Expected 6 drops for message, got %v", stats.DroppedByMessage)
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: &buf}),
+		WithRateLimit(RateLimit{Limit: 3, Interval: time.Minute}))
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		l.Info(ctx, "noisy")
+		l.Info(ctx, "quiet")
+	}
+
+	if got := strings.Count(buf.String(), "noisy"); got != 3 {
+		t.Errorf("Expected 3 noisy entries, got %d", got)
+	}
+	if got := strings.Count(buf.String(), "quiet"); got != 3 {
+		t.Errorf("Expected 3 quiet entries, got %d", got)
+	}
+
+	stats := l.Stats()
+	if stats.RateLimited != 4 {
+		t.Errorf("Expected 4 rate limited drops, got %d", stats.RateLimited)
+	}
+	if stats.DroppedByMessage["noisy"] != 2 || stats.DroppedByMessage["quiet"] != 2 {
+		t.Errorf("Unexpected per-message drops: %v", stats.DroppedByMessage)
+	}
+}
+
+func TestRateLimiterWindowReset(t *testing.T) {
+	r := &rateLimiter{limit: 1, interval: time.Second, windows: map[string]*rateWindow{}}
+	now := time.Now()
+
+	if !r.allow("msg", now) {
+		t.Error("Expected first entry to be allowed")
+	}
+	if r.allow("msg", now.Add(time.Millisecond)) {
+		t.Error("Expected second entry in window to be dropped")
+	}
+	if !r.allow("msg", now.Add(time.Second)) {
+		t.Error("Expected entry in next window to be allowed")
+	}
+}
+
+func TestRateLimitBoundedMessages(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: &buf}),
+		WithRateLimit(RateLimit{Limit: 1, Interval: time.Minute}))
+
+	ctx := context.Background()
+	for i := 0; i < maxRateLimitKeys+500; i++ {
+		msg := fmt.Sprintf("distinct %d", i)
+		l.Info(ctx, msg)
+		l.Info(ctx, msg)
+	}
+
+	stats := l.Stats()
+	if len(stats.DroppedByMessage) > maxDroppedMessages+1 {
+		t.Errorf("Expected at most %d drop keys, got %d", maxDroppedMessages+1, len(stats.DroppedByMessage))
+	}
+	if stats.DroppedByMessage[OtherMessages] == 0 {
+		t.Error("Expected overflow drops to be folded into OtherMessages")
+	}
+
+	limiter := l.core.(*rateLimitCore).limiter
+	if got := len(limiter.windows); got > maxRateLimitKeys+1 {
+		t.Errorf("Expected at most %d rate limit windows, got %d", maxRateLimitKeys+1, got)
+	}
+}
+
+func TestRateLimiterSweepsOncePerInterval(t *testing.T) {
+	r := &rateLimiter{limit: 1, interval: time.Minute, windows: map[string]*rateWindow{}}
+	start := time.Now()
+	for i := 0; i < maxRateLimitKeys; i++ {
+		r.allow(fmt.Sprintf("msg %d", i), start)
+	}
+
+	// Full and nothing expired: the sweep runs once and later messages share
+	// the overflow window without rescanning.
+	r.allow("new 1", start.Add(time.Second))
+	swept := r.lastSweep
+	r.allow("new 2", start.Add(2*time.Second))
+	if !r.lastSweep.Equal(swept) {
+		t.Errorf("Expected no second sweep within the interval, swept at %v and %v", swept, r.lastSweep)
+	}
+	if _, ok := r.windows["new 2"]; ok {
+		t.Error("Expected new message to share the overflow window")
+	}
+
+	// Once the interval passes the next new message sweeps expired windows.
+	later := start.Add(2 * time.Minute)
+	if !r.allow("fresh", later) {
+		t.Error("Expected fresh message to be allowed")
+	}
+	if _, ok := r.windows["fresh"]; !ok || len(r.windows) > 2 {
+		t.Errorf("Expected expired windows to be evicted, got %d windows", len(r.windows))
+	}
+}
@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"io"
+	"os"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type Sink struct {
+	Writer   io.Writer
+	Encoding Encoding
+	// Encoder overrides Encoding and Color when set.
+	Encoder zapcore.Encoder
+	// Level is the minimum level for this sink on top of the logger level.
+	// A nil Level follows the logger level only.
+	Level zapcore.LevelEnabler
+	// Color enables coloured levels for console encoding.
+	Color bool
+}
+
+func StdoutSink(encoding Encoding, level zapcore.LevelEnabler) Sink {
+	return Sink{
+		Writer:   os.Stdout,
+		Encoding: encoding,
+		Level:    level,
+	}
+}
+
+func StderrSink(encoding Encoding, level zapcore.LevelEnabler) Sink {
+	return Sink{
+		Writer:   os.Stderr,
+		Encoding: encoding,
+		Level:    level,
+	}
+}
+
+func WithSink(sinks ...Sink) Option {
+	return func(c *config) {
+		c.sinks = append(c.sinks, sinks...)
+	}
+}
+
+func (s Sink) core(cfg *config) zapcore.Core {
+	encoder := s.Encoder
+	if encoder == nil {
+		encoder = cfg.newEncoder(s.Encoding, s.Color)
+	}
+
+	var enabler zapcore.LevelEnabler = zapcore.DebugLevel
+	if s.Level != nil {
+		enabler = s.Level
+	}
+
+	return zapcore.NewCore(encoder, cfg.writeSyncer(zapcore.Lock(zapcore.AddSync(s.Writer))), enabler)
+}
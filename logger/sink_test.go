@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithSink(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "tee.log")
+	var jsonBuf, consoleBuf bytes.Buffer
+
+	l := NewLogger(
+		WithFile(logPath),
+		WithSink(
+			Sink{Writer: &jsonBuf, Encoding: EncodingJSON},
+			Sink{Writer: &consoleBuf, Encoding: EncodingConsole, Level: zapcore.WarnLevel, Color: true},
+		),
+	)
+
+	ctx := context.Background()
+	l.Info(ctx, "info entry")
+	l.Warn(ctx, "warn entry")
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "info entry") || !strings.Contains(string(content), "warn entry") {
+		t.Errorf("Expected both entries in file, got %s", content)
+	}
+
+	lines := strings.Split(strings.TrimSpace(jsonBuf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 JSON lines, got %d: %s", len(lines), jsonBuf.String())
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Errorf("Expected JSON sink output, got %s", lines[0])
+	}
+
+	if strings.Contains(consoleBuf.String(), "info entry") {
+		t.Error("Expected info entry to be filtered by console sink level")
+	}
+	if !strings.Contains(consoleBuf.String(), "warn entry") {
+		t.Errorf("Expected warn entry in console sink, got %s", consoleBuf.String())
+	}
+	if !strings.Contains(consoleBuf.String(), "\x1b[") {
+		t.Errorf("Expected coloured level in console sink, got %q", consoleBuf.String())
+	}
+}
+
+func TestWithoutFile(t *testing.T) {
+	dir := t.TempDir()
+	var buf bytes.Buffer
+
+	l := NewLogger(WithFile(filepath.Join(dir, "unused", "app.log")), WithoutFile(), WithSink(Sink{Writer: &buf}))
+	l.Info(context.Background(), "stdout only")
+
+	if _, err := os.Stat(filepath.Join(dir, "unused")); !os.IsNotExist(err) {
+		t.Error("Expected no log directory to be created without a file sink")
+	}
+	if !strings.Contains(buf.String(), "stdout only") {
+		t.Errorf("Expected entry in sink, got %s", buf.String())
+	}
+}
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: &buf}))
+	sl := slog.New(NewSlogHandler(l))
+
+	ctx := ContextWithLogID(context.Background(), "slog-id")
+	sl.DebugContext(ctx, "dropped debug")
+	sl.With("service", "api").WithGroup("req").InfoContext(ctx, "handled",
+		"status", 200, slog.Group("timing", slog.Duration("total", time.Second)))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 line, got %d: %s", len(lines), buf.String())
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["msg"] != "handled" || entry[LogIDKey] != "slog-id" || entry["service"] != "api" {
+		t.Errorf("Unexpected entry %v", entry)
+	}
+	req, _ := entry["req"].(map[string]interface{})
+	if req["status"] != float64(200) {
+		t.Errorf("Expected status nested under req group, got %v", entry)
+	}
+	if timing, _ := req["timing"].(map[string]interface{}); timing["total"] != float64(1) {
+		t.Errorf("Expected nested timing group, got %v", req)
+	}
+	if caller, _ := entry["caller"].(string); !strings.Contains(caller, "slog_test.go") {
+		t.Errorf("Expected caller from slog record, got %v", entry["caller"])
+	}
+}
+
+func TestSlogHandlerUsesContextLogger(t *testing.T) {
+	observed := NewObserved(t)
+	sl := NewSlogLogger()
+
+	sl.WarnContext(ContextWithLogID(context.Background(), "ctx-id"), "from slog", "k", "v")
+
+	entries := observed.All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].Level != zapcore.WarnLevel || entries[0].LogID != "ctx-id" || entries[0].Fields["k"] != "v" {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+}
+
+func TestNewFromSlog(t *testing.T) {
+	var buf bytes.Buffer
+	sl := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	l := NewFromSlog(sl)
+
+	ctx := ContextWithLogID(context.Background(), "bridge-id")
+	l.Debug(ctx, "dropped debug")
+	l.Warn(ctx, "to slog", zap.String("key", "value"), zap.Namespace("ns"), zap.Int("n", 1))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 line, got %d: %s", len(lines), buf.String())
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["level"] != "WARN" || entry["msg"] != "to slog" || entry[LogIDKey] != "bridge-id" || entry["key"] != "value" {
+		t.Errorf("Unexpected entry %v", entry)
+	}
+	if ns, _ := entry["ns"].(map[string]interface{}); ns["n"] != float64(1) {
+		t.Errorf("Expected namespace as slog group, got %v", entry)
+	}
+}
+
+func TestSlogHandlerNameAndStacktrace(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithoutFile(), WithSink(Sink{Writer: &buf}), WithStacktrace(zapcore.WarnLevel))
+	sl := slog.New(NewSlogHandler(l.Named("db")))
+
+	sl.Info("query")
+	sl.Warn("slow query")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %s", len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry["logger"] != "db" {
+			t.Errorf("Expected logger name db, got %v", entry["logger"])
+		}
+		if _, ok := entry["stacktrace"]; ok != (i == 1) {
+			t.Errorf("Unexpected stacktrace presence in %v", entry)
+		}
+	}
+}
@@ -1,15 +1,22 @@
 package util
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"github.com/go-resty/resty/v2"
+
+	toolctx "github.com/marcuspeh/go-tools/ctx"
 )
 
 func GetRequest[reqStruct, respStruct any](url string, req *reqStruct) (*respStruct, error) {
-	client := resty.New()
+	return GetRequestCtx[reqStruct, respStruct](context.Background(), url, req)
+}
 
+// GetRequestCtx is GetRequest bound to ctx, forwarding its log ID, trace
+// context and baggage.
+func GetRequestCtx[reqStruct, respStruct any](ctx context.Context, url string, req *reqStruct) (*respStruct, error) {
 	marshalledReq, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
@@ -27,7 +34,7 @@ func GetRequest[reqStruct, respStruct any](url string, req *reqStruct) (*respStr
 	}
 
 	var respModel respStruct
-	resp, err := client.R().
+	resp, err := newRequest(ctx).
 		SetQueryParams(params).
 		SetResult(&respModel).
 		ForceContentType("application/json").
@@ -42,10 +49,14 @@ func GetRequest[reqStruct, respStruct any](url string, req *reqStruct) (*respStr
 }
 
 func PostRequest[reqStruct, respStruct any](url string, req *reqStruct) (*respStruct, error) {
-	client := resty.New()
+	return PostRequestCtx[reqStruct, respStruct](context.Background(), url, req)
+}
 
+// PostRequestCtx is PostRequest bound to ctx, forwarding its log ID, trace
+// context and baggage.
+func PostRequestCtx[reqStruct, respStruct any](ctx context.Context, url string, req *reqStruct) (*respStruct, error) {
 	var respModel respStruct
-	resp, err := client.R().
+	resp, err := newRequest(ctx).
 		SetBody(req).
 		SetResult(&respModel).
 		ForceContentType("application/json").
@@ -60,10 +71,14 @@ func PostRequest[reqStruct, respStruct any](url string, req *reqStruct) (*respSt
 }
 
 func DeleteRequest[reqStruct, respStruct any](url string, req *reqStruct) (*respStruct, error) {
-	client := resty.New()
+	return DeleteRequestCtx[reqStruct, respStruct](context.Background(), url, req)
+}
 
+// DeleteRequestCtx is DeleteRequest bound to ctx, forwarding its log ID, trace
+// context and baggage.
+func DeleteRequestCtx[reqStruct, respStruct any](ctx context.Context, url string, req *reqStruct) (*respStruct, error) {
 	var respModel respStruct
-	resp, err := client.R().
+	resp, err := newRequest(ctx).
 		SetBody(req).
 		SetResult(&respModel).
 		ForceContentType("application/json").
@@ -76,3 +91,9 @@ func DeleteRequest[reqStruct, respStruct any](url string, req *reqStruct) (*resp
 	}
 	return &respModel, nil
 }
+
+func newRequest(ctx context.Context) *resty.Request {
+	r := resty.New().R().SetContext(ctx)
+	toolctx.InjectHeaders(ctx, r.Header)
+	return r
+}
@@ -1,10 +1,15 @@
 package util
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	toolctx "github.com/marcuspeh/go-tools/ctx"
 )
 
 type TestReq struct {
@@ -123,3 +128,46 @@ func TestRequestError(t *testing.T) {
 		t.Error("Expected error for 500")
 	}
 }
+
+func TestRequestCtxForwardsLogID(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get(toolctx.LogIDHeader()))
+		json.NewEncoder(w).Encode(TestResp{Success: true})
+	}))
+	defer server.Close()
+
+	ctx := toolctx.WithLogID(context.Background(), "forwarded-id")
+	req := &TestReq{ID: 1, Name: "ctx"}
+	if _, err := GetRequestCtx[TestReq, TestResp](ctx, server.URL, req); err != nil {
+		t.Fatalf("GetRequestCtx failed: %v", err)
+	}
+	if _, err := PostRequestCtx[TestReq, TestResp](ctx, server.URL, req); err != nil {
+		t.Fatalf("PostRequestCtx failed: %v", err)
+	}
+	if _, err := DeleteRequestCtx[TestReq, TestResp](ctx, server.URL, req); err != nil {
+		t.Fatalf("DeleteRequestCtx failed: %v", err)
+	}
+	if _, err := GetRequest[TestReq, TestResp](server.URL, req); err != nil {
+		t.Fatalf("GetRequest failed: %v", err)
+	}
+
+	want := []string{"forwarded-id", "forwarded-id", "forwarded-id", ""}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected headers %v, got %v", want, got)
+	}
+}
+
+func TestRequestCtxCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(TestResp{Success: true})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := PostRequestCtx[TestReq, TestResp](ctx, server.URL, &TestReq{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}